@@ -0,0 +1,158 @@
+package main
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+	"github.com/tembleking/coverflex-mcp/internal/infra/coverflex"
+	"github.com/tembleking/coverflex-mcp/internal/infra/fs"
+	"github.com/tembleking/coverflex-mcp/internal/ledger"
+)
+
+// Environment variables used as fallback for the HTTP transport flags.
+const (
+	envTimeout               = "COVERFLEX_TIMEOUT"
+	envDialTimeout           = "COVERFLEX_DIAL_TIMEOUT"
+	envTLSHandshakeTimeout   = "COVERFLEX_TLS_HANDSHAKE_TIMEOUT"
+	envResponseHeaderTimeout = "COVERFLEX_RESPONSE_HEADER_TIMEOUT"
+	envProxy                 = "COVERFLEX_PROXY"
+	envCACerts               = "COVERFLEX_CA_CERTS"
+	envLocale                = "COVERFLEX_LOCALE"
+	envTimezone              = "COVERFLEX_TIMEZONE"
+	envLedger                = "COVERFLEX_LEDGER"
+)
+
+// newClient creates a Coverflex client configured from the HTTP transport flags,
+// falling back to their environment variables when a flag is not set.
+// Any extra options are applied after the ones derived from the flags.
+func newClient(cmd *cobra.Command, tokenRepo domain.TokenRepository, extraOpts ...coverflex.ClientOption) (*coverflex.Client, error) {
+	var opts []coverflex.ClientOption
+
+	durationSettings := []struct {
+		flag   string
+		env    string
+		option func(time.Duration) coverflex.ClientOption
+	}{
+		{"timeout", envTimeout, coverflex.WithTimeout},
+		{"dial-timeout", envDialTimeout, coverflex.WithDialTimeout},
+		{"tls-handshake-timeout", envTLSHandshakeTimeout, coverflex.WithTLSHandshakeTimeout},
+		{"response-header-timeout", envResponseHeaderTimeout, coverflex.WithResponseHeaderTimeout},
+	}
+	for _, setting := range durationSettings {
+		value, err := durationSetting(cmd, setting.flag, setting.env)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, setting.option(value))
+	}
+
+	proxy := stringSetting(cmd, "proxy", envProxy)
+	if proxy != "" {
+		proxyURL, err := url.Parse(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %q: %w", proxy, err)
+		}
+		opts = append(opts, coverflex.WithProxy(proxyURL))
+	}
+
+	if locale := stringSetting(cmd, "locale", envLocale); locale != "" {
+		opts = append(opts, coverflex.WithLocale(locale))
+	}
+
+	caCerts, _ := cmd.Flags().GetStringSlice("ca-cert")
+	if !cmd.Flags().Changed("ca-cert") {
+		caCerts = filepath.SplitList(os.Getenv(envCACerts))
+	}
+	if len(caCerts) > 0 {
+		pool, err := loadCertPool(caCerts)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, coverflex.WithRootCAs(pool))
+	}
+
+	return coverflex.NewClient(tokenRepo, append(opts, extraOpts...)...), nil
+}
+
+// newLedgerStore opens the operations ledger at the --ledger path or its environment variable,
+// or in the user cache directory by default.
+func newLedgerStore(cmd *cobra.Command) *ledger.Store {
+	return ledger.NewStore(fs.NewLedgerRepository(stringSetting(cmd, "ledger", envLedger)))
+}
+
+// applyDisplayTimezone sets the timezone timestamps are displayed in from the --timezone flag or its environment variable.
+func applyDisplayTimezone(cmd *cobra.Command) error {
+	timezone := stringSetting(cmd, "timezone", envTimezone)
+	if timezone == "" {
+		return nil
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return fmt.Errorf("invalid timezone %q: %w", timezone, err)
+	}
+	domain.SetDisplayLocation(loc)
+	return nil
+}
+
+// loadCertPool returns the system certificate pool extended with the PEM certificates in the given files.
+func loadCertPool(paths []string) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		pem, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not read CA certificate file: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid PEM certificates found in %s", path)
+		}
+	}
+	return pool, nil
+}
+
+func stringSetting(cmd *cobra.Command, flag, env string) string {
+	if cmd.Flags().Changed(flag) {
+		value, _ := cmd.Flags().GetString(flag)
+		return value
+	}
+	return os.Getenv(env)
+}
+
+func durationSetting(cmd *cobra.Command, flag, env string) (time.Duration, error) {
+	if cmd.Flags().Changed(flag) {
+		return cmd.Flags().GetDuration(flag)
+	}
+	value := os.Getenv(env)
+	if value == "" {
+		return 0, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration in %s: %w", env, err)
+	}
+	return duration, nil
+}
+
+func init() {
+	flags := rootCmd.PersistentFlags()
+	flags.Duration("timeout", coverflex.DefaultTimeout, "Overall time limit for each Coverflex API request. Env: "+envTimeout)
+	flags.Duration("dial-timeout", coverflex.DefaultDialTimeout, "Time limit for establishing a connection. Env: "+envDialTimeout)
+	flags.Duration("tls-handshake-timeout", coverflex.DefaultTLSHandshakeTimeout, "Time limit for the TLS handshake. Env: "+envTLSHandshakeTimeout)
+	flags.Duration("response-header-timeout", coverflex.DefaultResponseHeaderTimeout, "Time limit for receiving the response headers. Env: "+envResponseHeaderTimeout)
+	flags.String("proxy", "", "HTTP(S) proxy URL. Defaults to the HTTPS_PROXY/HTTP_PROXY environment variables. Env: "+envProxy)
+	flags.String("locale", "", "Language of the Coverflex content and server messages (e.g. pt, es, en). Defaults to the first language of the company market. Env: "+envLocale)
+	flags.String("timezone", domain.DefaultDisplayTimezone, "IANA timezone used to display timestamps and to decide which day an operation belongs to. Env: "+envTimezone)
+	flags.String("ledger", "", "Path of the local operations ledger file. Defaults to "+fs.DefaultLedgerPath()+". Env: "+envLedger)
+	flags.StringSlice("ca-cert", nil, "Additional PEM CA certificate files to trust, e.g. for TLS-intercepting proxies. Env: "+envCACerts+" (path list)")
+}
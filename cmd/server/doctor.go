@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+	"github.com/tembleking/coverflex-mcp/internal/i18n"
+	"github.com/tembleking/coverflex-mcp/internal/infra/coverflex"
+	"github.com/tembleking/coverflex-mcp/internal/infra/fs"
+)
+
+// doctorCmd groups the diagnostic commands
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose problems with the Coverflex integration",
+}
+
+// doctorAPICmd represents the doctor api command
+var doctorAPICmd = &cobra.Command{
+	Use:   "api",
+	Short: "Detect drift between the Coverflex API and the decoded structs",
+	Long: `The 'doctor api' command calls every Coverflex endpoint used by the MCP server with strict
+decoding enabled, and prints a report of the fields the API returns that are ignored (unknown),
+the fields the server expects that the API no longer returns (missing), and the fields whose
+JSON kind changed, such as an object where a string is expected (mismatched).
+
+It exits with a non-zero status if any endpoint fails or shows drift. You must be logged in.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+		slog.SetDefault(logger)
+
+		tokenRepo := fs.NewTokenRepository()
+		client, err := newClient(cmd, tokenRepo, coverflex.WithStrictDecoding())
+		if err != nil {
+			slog.Error("Invalid client configuration", "error", err)
+			os.Exit(1)
+		}
+		if !client.IsLoggedIn() {
+			slog.Error("You are not logged in. Please run the 'login' command first.")
+			os.Exit(1)
+		}
+
+		ctx := cmd.Context()
+		checks := []struct {
+			name string
+			call func(context.Context) error
+		}{
+			{"company", func(ctx context.Context) error { _, err := client.GetCompany(ctx); return err }},
+			{"compensation", func(ctx context.Context) error { _, err := client.GetCompensation(ctx); return err }},
+			{"benefits", func(ctx context.Context) error { _, err := client.GetBenefits(ctx); return err }},
+			{"cards", func(ctx context.Context) error { _, err := client.GetCards(ctx); return err }},
+			{"family", func(ctx context.Context) error { _, err := client.GetFamily(ctx); return err }},
+			{"operations", func(ctx context.Context) error { _, err := client.GetOperations(ctx); return err }},
+		}
+
+		failed := false
+		out := cmd.OutOrStdout()
+		for _, check := range checks {
+			if err := check.call(ctx); err != nil {
+				failed = true
+				_, _ = fmt.Fprintf(out, "✗ %s: request failed: %v\n", check.name, err)
+			}
+		}
+
+		for _, report := range client.SchemaDrift() {
+			if !report.HasDrift() {
+				_, _ = fmt.Fprintf(out, "✓ %s: no drift\n", report.Endpoint)
+				continue
+			}
+			failed = true
+			_, _ = fmt.Fprintf(out, "✗ %s: drift detected\n", report.Endpoint)
+			if len(report.Unknown) > 0 {
+				_, _ = fmt.Fprintf(out, "    unknown fields: %s\n", strings.Join(report.Unknown, ", "))
+			}
+			if len(report.Missing) > 0 {
+				_, _ = fmt.Fprintf(out, "    missing fields: %s\n", strings.Join(report.Missing, ", "))
+			}
+			if len(report.Mismatched) > 0 {
+				_, _ = fmt.Fprintf(out, "    mismatched fields: %s\n", strings.Join(report.Mismatched, ", "))
+			}
+		}
+
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+// doctorDescriptionsCmd represents the doctor descriptions command
+var doctorDescriptionsCmd = &cobra.Command{
+	Use:   "descriptions",
+	Short: "Report operation description tags without a human-readable template",
+	Long: `The 'doctor descriptions' command renders the description of the most recent operations
+and reports the description tags that have no template in the message catalogs, together with
+the param keys seen with them, so they can be added.
+
+It exits with a non-zero status if any unmapped tag is found. You must be logged in.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+		slog.SetDefault(logger)
+
+		tokenRepo := fs.NewTokenRepository()
+		client, err := newClient(cmd, tokenRepo)
+		if err != nil {
+			slog.Error("Invalid client configuration", "error", err)
+			os.Exit(1)
+		}
+		if !client.IsLoggedIn() {
+			slog.Error("You are not logged in. Please run the 'login' command first.")
+			os.Exit(1)
+		}
+
+		pages, _ := cmd.Flags().GetInt("pages")
+		renderer := i18n.NewDescriptionRenderer()
+		rendered := 0
+		for page := 1; page <= pages; page++ {
+			operations, err := client.GetOperations(cmd.Context(), domain.WithOperationsPage(page), domain.WithOperationsPerPage(50))
+			if err != nil {
+				slog.Error("Failed to fetch operations", "page", page, "error", err)
+				os.Exit(1)
+			}
+			for _, operation := range operations {
+				renderer.Render(i18n.DefaultLanguage, operation.DescriptionTag, operation.Params())
+				rendered++
+			}
+			if len(operations) < 50 {
+				break
+			}
+		}
+
+		out := cmd.OutOrStdout()
+		unmapped := renderer.Unmapped()
+		if len(unmapped) == 0 {
+			_, _ = fmt.Fprintf(out, "✓ all %d operation descriptions are mapped\n", rendered)
+			return
+		}
+		for _, tag := range unmapped {
+			_, _ = fmt.Fprintf(out, "✗ %s: %d operations, params: %s\n", tag.Tag, tag.Count, strings.Join(tag.ParamKeys, ", "))
+		}
+		os.Exit(1)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+	doctorCmd.AddCommand(doctorAPICmd)
+	doctorCmd.AddCommand(doctorDescriptionsCmd)
+
+	doctorDescriptionsCmd.Flags().Int("pages", 5, "Number of pages of 50 operations to inspect.")
+}
@@ -5,7 +5,6 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
-	"github.com/tembleking/coverflex-mcp/internal/infra/coverflex"
 	"github.com/tembleking/coverflex-mcp/internal/infra/fs"
 )
 
@@ -25,7 +24,11 @@ useful when existing tokens are expired or invalid.`,
 		slog.SetDefault(logger)
 
 		tokenRepo := fs.NewTokenRepository()
-		client := coverflex.NewClient(tokenRepo)
+		client, err := newClient(cmd, tokenRepo)
+		if err != nil {
+			slog.Error("Invalid client configuration", "error", err)
+			os.Exit(1)
+		}
 
 		user, _ := cmd.Flags().GetString("user")
 		pass, _ := cmd.Flags().GetString("pass")
@@ -40,7 +43,7 @@ useful when existing tokens are expired or invalid.`,
 				slog.Error("Refresh token file not found. Cannot force refresh. Please log in first.")
 				os.Exit(1)
 			}
-			newAuthToken, _ := client.RefreshTokens(tokens.RefreshToken)
+			newAuthToken, _ := client.RefreshTokens(cmd.Context(), tokens.RefreshToken)
 			if newAuthToken != "" {
 				slog.Info("\nTokens have been refreshed.")
 			} else {
@@ -58,7 +61,7 @@ useful when existing tokens are expired or invalid.`,
 
 		if user != "" && pass != "" && otp != "" {
 			slog.Info("User, password, and OTP provided. Attempting to log in...")
-			if err := client.Login(user, pass, otp); err != nil {
+			if err := client.Login(cmd.Context(), user, pass, otp); err != nil {
 				slog.Error("Login failed", "error", err)
 				os.Exit(1)
 			}
@@ -68,7 +71,7 @@ useful when existing tokens are expired or invalid.`,
 
 		if user != "" && pass != "" {
 			slog.Info("User and password provided. Requesting OTP...")
-			if err := client.RequestOTP(user, pass); err != nil {
+			if err := client.RequestOTP(cmd.Context(), user, pass); err != nil {
 				slog.Error("Failed to request OTP", "error", err)
 				os.Exit(1)
 			}
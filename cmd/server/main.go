@@ -1,5 +1,7 @@
 package main
 
+import _ "time/tzdata" // Embed the timezone database, so Europe/Lisbon is available everywhere.
+
 func main() {
 	Execute()
 }
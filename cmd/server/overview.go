@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+	"github.com/tembleking/coverflex-mcp/internal/infra/fs"
+)
+
+// overviewCmd represents the overview command
+var overviewCmd = &cobra.Command{
+	Use:   "overview",
+	Short: "Print a full overview of the Coverflex account",
+	Long: `The 'overview' command fetches the company, compensation, benefits, cards, family members
+and recent operations concurrently, and prints them as JSON. Refunds among the recent
+operations are linked to the payments they refund.
+
+Sections that fail to load are reported under 'errors' instead of failing the whole command.
+Use '--parallelism' to limit how many requests are made at the same time.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+		slog.SetDefault(logger)
+
+		tokenRepo := fs.NewTokenRepository()
+		client, err := newClient(cmd, tokenRepo)
+		if err != nil {
+			slog.Error("Invalid client configuration", "error", err)
+			os.Exit(1)
+		}
+		if !client.IsLoggedIn() {
+			slog.Error("You are not logged in. Please run the 'login' command first.")
+			os.Exit(1)
+		}
+
+		parallelism, _ := cmd.Flags().GetInt("parallelism")
+		overview := client.GetOverview(cmd.Context(), domain.WithOverviewParallelism(parallelism))
+
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(overview); err != nil {
+			slog.Error("Failed to encode overview", "error", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(overviewCmd)
+
+	overviewCmd.Flags().Int("parallelism", domain.DefaultOverviewParallelism, "Maximum number of Coverflex endpoints fetched at the same time.")
+}
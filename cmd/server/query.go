@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/tembleking/coverflex-mcp/internal/i18n"
+	"github.com/tembleking/coverflex-mcp/internal/infra/fs"
+	"github.com/tembleking/coverflex-mcp/internal/ledger"
+	"github.com/tembleking/coverflex-mcp/internal/query"
+)
+
+// queryCmd represents the query command
+var queryCmd = &cobra.Command{
+	Use:   "query <query>",
+	Short: "Run a query over the Coverflex operations",
+	Long: `The 'query' command runs a small SQL-like query over the operations and prints the result as a table:
+
+  [select expr [as name], ...] [where condition] [group by field, ...] [order by expr [asc|desc], ...] [limit n]
+
+Aggregates are count(*), count(expr), sum, avg, min and max, and conditions use =, !=, <, <=, >, >=,
+~ (contains), in (...), and, or and not. Strings are compared ignoring case and accents, and numbers
+compared with money are euros. For example:
+
+  coverflex-mcp query "select month, sum(amount) as total where is_debit group by month order by month desc"
+
+The operations are read from the API, or from the local ledger with '--source ledger'.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+		slog.SetDefault(logger)
+
+		q, err := query.Parse(args[0])
+		if err != nil {
+			slog.Error("Invalid query", "error", err)
+			os.Exit(1)
+		}
+
+		source, _ := cmd.Flags().GetString("source")
+		if !slices.Contains(ledger.Sources, source) {
+			slog.Error("Invalid source", "source", source, "expected", strings.Join(ledger.Sources, ", "))
+			os.Exit(1)
+		}
+		tokenRepo := fs.NewTokenRepository()
+		client, err := newClient(cmd, tokenRepo)
+		if err != nil {
+			slog.Error("Invalid client configuration", "error", err)
+			os.Exit(1)
+		}
+		if source == ledger.SourceAPI && !client.IsLoggedIn() {
+			slog.Error("You are not logged in. Please run the 'login' command first.")
+			os.Exit(1)
+		}
+
+		ctx := ledger.WithSource(cmd.Context(), source)
+		maxPages, _ := cmd.Flags().GetInt("max-pages")
+		operations, incomplete, err := query.Load(ctx, ledger.NewService(client, newLedgerStore(cmd)), maxPages)
+		if err != nil {
+			slog.Error("Failed to load operations", "error", err)
+			os.Exit(1)
+		}
+		renderer := i18n.NewDescriptionRenderer()
+		for i := range operations {
+			operations[i].Description = renderer.Render(i18n.DefaultLanguage, operations[i].DescriptionTag, operations[i].Params())
+		}
+
+		result, err := q.Run(operations)
+		if err != nil {
+			slog.Error("Query failed", "error", err)
+			os.Exit(1)
+		}
+		if incomplete {
+			slog.Warn("The page limit was reached, older operations were left out; raise --max-pages", "operations", len(operations))
+		}
+
+		out := cmd.OutOrStdout()
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			encoder := json.NewEncoder(out)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(result); err != nil {
+				slog.Error("Failed to encode result", "error", err)
+				os.Exit(1)
+			}
+			return
+		}
+
+		table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		header := make([]string, 0, len(result.Columns))
+		for _, column := range result.Columns {
+			header = append(header, column.Name)
+		}
+		_, _ = fmt.Fprintln(table, strings.Join(header, "\t"))
+		for _, row := range result.Rows {
+			cells := make([]string, 0, len(row))
+			for _, value := range row {
+				cells = append(cells, value.Text())
+			}
+			_, _ = fmt.Fprintln(table, strings.Join(cells, "\t"))
+		}
+		_ = table.Flush()
+		if result.Truncated {
+			_, _ = fmt.Fprintln(out, "more rows were left out by the limit")
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(queryCmd)
+
+	queryCmd.Flags().String("source", ledger.SourceAPI, "Where to read the operations from: "+strings.Join(ledger.Sources, " or ")+".")
+	queryCmd.Flags().Int("max-pages", 20, "Maximum number of pages of 50 operations to load, 0 meaning no limit.")
+	queryCmd.Flags().Bool("json", false, "Print the result as JSON instead of a table.")
+}
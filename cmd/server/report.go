@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+	"github.com/tembleking/coverflex-mcp/internal/infra/fs"
+	"github.com/tembleking/coverflex-mcp/internal/ledger"
+)
+
+// reportCmd represents the report command
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Print reports about the Coverflex operations",
+	Long:  `The 'report' command groups the reports computed from the operations, such as 'report spending'.`,
+}
+
+// reportSpendingCmd represents the report spending command
+var reportSpendingCmd = &cobra.Command{
+	Use:   "spending",
+	Short: "Print the spending by category, product and period",
+	Long: `The 'report spending' command adds up the payments over a period, the current month by default, and
+prints the total by benefit category and product and by day, week or month.
+
+Totals are net of the refunds linked to each payment and leave out rollovers and reversed or failed
+payments. The period is set with '--from' and '--to', or with '--range', e.g. '--range last_month'.
+The operations are read from the API, or from the local ledger with '--source ledger'.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+		slog.SetDefault(logger)
+
+		by, _ := cmd.Flags().GetString("by")
+		granularity, err := domain.ParseSpendingGranularity(by)
+		if err != nil {
+			slog.Error("Invalid granularity", "error", err)
+			os.Exit(1)
+		}
+		source, _ := cmd.Flags().GetString("source")
+		if !slices.Contains(ledger.Sources, source) {
+			slog.Error("Invalid source", "source", source, "expected", strings.Join(ledger.Sources, ", "))
+			os.Exit(1)
+		}
+		tokenRepo := fs.NewTokenRepository()
+		client, err := newClient(cmd, tokenRepo)
+		if err != nil {
+			slog.Error("Invalid client configuration", "error", err)
+			os.Exit(1)
+		}
+		if source == ledger.SourceAPI && !client.IsLoggedIn() {
+			slog.Error("You are not logged in. Please run the 'login' command first.")
+			os.Exit(1)
+		}
+
+		ctx := ledger.WithSource(cmd.Context(), source)
+		period, err := reportPeriod(ctx, cmd, client)
+		if err != nil {
+			slog.Error("Invalid period", "error", err)
+			os.Exit(1)
+		}
+		maxPages, _ := cmd.Flags().GetInt("max-pages")
+		summary, incomplete, err := domain.LoadSpending(ctx, ledger.NewService(client, newLedgerStore(cmd)), period, granularity, maxPages)
+		if err != nil {
+			slog.Error("Failed to summarize the spending", "error", err)
+			os.Exit(1)
+		}
+		if incomplete {
+			slog.Warn("The page limit was reached, older operations were left out; raise --max-pages")
+		}
+
+		out := cmd.OutOrStdout()
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			encoder := json.NewEncoder(out)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(summary); err != nil {
+				slog.Error("Failed to encode the summary", "error", err)
+				os.Exit(1)
+			}
+			return
+		}
+
+		_, _ = fmt.Fprintf(out, "Spending from %s to %s: %s in %d payments, %s refunded\n\n",
+			summary.Range.From, summary.Range.To, summary.Total, summary.Payments, summary.Refunded)
+		table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(table, "category\tproduct\ttotal\tpayments\trefunded")
+		for _, category := range summary.ByCategory {
+			_, _ = fmt.Fprintf(table, "%s\t\t%s\t%d\t%s\n", category.Category, category.Total, category.Payments, category.Refunded)
+			for _, product := range category.Products {
+				_, _ = fmt.Fprintf(table, "\t%s\t%s\t%d\t%s\n", product.Product, product.Total, product.Payments, product.Refunded)
+			}
+		}
+		_ = table.Flush()
+		_, _ = fmt.Fprintln(out)
+		table = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(table, string(summary.Granularity)+"\ttotal\tpayments\trefunded")
+		for _, period := range summary.ByPeriod {
+			_, _ = fmt.Fprintf(table, "%s\t%s\t%d\t%s\n", period.Period, period.Total, period.Payments, period.Refunded)
+		}
+		_ = table.Flush()
+	},
+}
+
+// reportPeriod resolves the --from, --to and --range flags, defaulting to the current month.
+func reportPeriod(ctx context.Context, cmd *cobra.Command, compensation domain.CompensationReader) (domain.DateRange, error) {
+	from, _ := cmd.Flags().GetString("from")
+	to, _ := cmd.Flags().GetString("to")
+	name, _ := cmd.Flags().GetString("range")
+	if name != "" && (from != "" || to != "") {
+		return domain.DateRange{}, fmt.Errorf("--range cannot be combined with --from or --to")
+	}
+
+	var period domain.DateRange
+	var err error
+	switch {
+	case name != "":
+		var renewal domain.Date
+		if domain.IsBenefitPeriodRange(name) {
+			summary, err := compensation.GetCompensation(ctx)
+			if err != nil {
+				return domain.DateRange{}, fmt.Errorf("error getting the benefit renewal date: %w", err)
+			}
+			renewal = summary.RenewalDate
+		}
+		period, err = domain.ResolveRelativeRange(name, domain.Today(), renewal)
+	case from != "" || to != "":
+		period, err = domain.ParseDateRange(from, to)
+	default:
+		period, err = domain.ResolveRelativeRange(domain.RangeCurrentMonth, domain.Today(), domain.Date{})
+	}
+	if err != nil {
+		return domain.DateRange{}, err
+	}
+	return period, period.Validate()
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.AddCommand(reportSpendingCmd)
+
+	reportSpendingCmd.Flags().String("from", "", "First day of the period, as YYYY-MM-DD, or a month as YYYY-MM.")
+	reportSpendingCmd.Flags().String("to", "", "Last day of the period, as YYYY-MM-DD, or a month as YYYY-MM.")
+	reportSpendingCmd.Flags().String("range", "", "A relative period instead of --from and --to: "+strings.Join(domain.RelativeRanges, ", ")+".")
+	reportSpendingCmd.Flags().String("by", string(domain.SpendingByMonth), "Break the spending down by day, week or month.")
+	reportSpendingCmd.Flags().String("source", ledger.SourceAPI, "Where to read the operations from: "+strings.Join(ledger.Sources, " or ")+".")
+	reportSpendingCmd.Flags().Int("max-pages", 20, "Maximum number of pages of 50 operations to walk, 0 meaning no limit.")
+	reportSpendingCmd.Flags().Bool("json", false, "Print the summary as JSON instead of tables.")
+}
@@ -1,14 +1,15 @@
 package main
 
 import (
-	"context"
 	"log/slog"
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/tembleking/coverflex-mcp/internal/i18n"
 	"github.com/tembleking/coverflex-mcp/internal/infra/coverflex"
 	"github.com/tembleking/coverflex-mcp/internal/infra/fs"
 	"github.com/tembleking/coverflex-mcp/internal/infra/mcp"
+	"github.com/tembleking/coverflex-mcp/internal/ledger"
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -20,26 +21,48 @@ your interactions with Coverflex services. It allows you to authenticate, manage
 and perform various operations directly from your terminal.
 
 Use 'coverflex-mcp [command] --help' for more information about a specific command.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return applyDisplayTimezone(cmd)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		logger := slog.New(slog.NewJSONHandler(os.Stderr, nil))
 		slog.SetDefault(logger)
 
 		tokenRepo := fs.NewTokenRepository()
-		client := coverflex.NewClient(tokenRepo)
+		client, err := newClient(cmd, tokenRepo, coverflex.WithStrictDecoding())
+		if err != nil {
+			slog.Error("Invalid client configuration", "error", err)
+			os.Exit(1)
+		}
+
+		renderer := i18n.NewDescriptionRenderer()
+		operations := ledger.NewService(client, newLedgerStore(cmd))
 
 		handler := mcp.NewHandlerWithTools(
 			mcp.NewToolGetBenefits(client),
 			mcp.NewToolGetCards(client),
+			mcp.NewToolGetCardStatus(client),
 			mcp.NewToolGetCompany(client),
 			mcp.NewToolGetCompensation(client),
 			mcp.NewToolGetFamily(client),
-			mcp.NewToolGetOperations(client),
+			mcp.NewToolGetFamilyInsights(client),
+			mcp.NewToolGetOperation(operations, renderer),
+			mcp.NewToolGetOperations(operations, renderer),
+			mcp.NewToolGetAllOperations(operations, renderer),
+			mcp.NewToolSearchOperations(operations, renderer),
+			mcp.NewToolQueryOperations(operations, renderer),
+			mcp.NewToolGetSpendingSummary(operations),
+			mcp.NewToolGetMerchantStats(operations),
+			mcp.NewToolGetBenefitUtilization(operations),
+			mcp.NewToolSyncOperations(operations),
+			mcp.NewToolGetOverview(client, renderer),
+			mcp.NewToolListOperationTypes(client),
 			mcp.NewToolTrustDeviceViaOTP(client),
 			mcp.NewToolIsLoggedIn(client),
 			mcp.NewToolRequestOTP(client),
 		)
 
-		if err := handler.ServeStdio(context.Background(), os.Stdin, os.Stdout); err != nil {
+		if err := handler.ServeStdio(cmd.Context(), os.Stdin, os.Stdout); err != nil {
 			slog.Error("MCP server error", "error", err)
 			os.Exit(1)
 		}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tembleking/coverflex-mcp/internal/infra/fs"
+	"github.com/tembleking/coverflex-mcp/internal/ledger"
+)
+
+// syncCmd represents the sync command
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync the local operations ledger with Coverflex",
+	Long: `The 'sync' command copies the Coverflex operations into a local ledger file, so the MCP tools
+can read them with source 'ledger' instead of walking the API.
+
+Only the pages since the last sync are fetched, going back far enough to see the operations still
+pending from the last 30 days, unless '--full' is given or a previous sync did not reach the oldest
+operation. Status changes of known operations, such as a pending payment being confirmed or reversed,
+are reported. You must be logged in.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+		slog.SetDefault(logger)
+
+		tokenRepo := fs.NewTokenRepository()
+		client, err := newClient(cmd, tokenRepo)
+		if err != nil {
+			slog.Error("Invalid client configuration", "error", err)
+			os.Exit(1)
+		}
+		if !client.IsLoggedIn() {
+			slog.Error("You are not logged in. Please run the 'login' command first.")
+			os.Exit(1)
+		}
+
+		full, _ := cmd.Flags().GetBool("full")
+		maxPages, _ := cmd.Flags().GetInt("max-pages")
+		result, err := ledger.NewService(client, newLedgerStore(cmd)).SyncOperations(cmd.Context(), full, maxPages)
+		if result == nil {
+			slog.Error("Failed to sync operations", "error", err)
+			os.Exit(1)
+		}
+
+		out := cmd.OutOrStdout()
+		mode := "incremental"
+		if result.Full {
+			mode = "full"
+		}
+		_, _ = fmt.Fprintf(out, "%s sync: %d fetched, %d new, %d updated, %d in the ledger\n",
+			mode, result.Fetched, result.New, result.Updated, result.Total)
+		for _, transition := range result.Transitions {
+			line := fmt.Sprintf("  %s: %s → %s", transition.OperationID, transition.From, transition.To)
+			if transition.Reversal {
+				line += " (reversal)"
+			}
+			_, _ = fmt.Fprintln(out, line)
+		}
+		if result.Truncated {
+			_, _ = fmt.Fprintln(out, "stopped at the page limit before reaching the synced operations; run again with a higher --max-pages")
+		}
+		if err != nil {
+			slog.Error("Sync did not complete", "error", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+
+	syncCmd.Flags().Bool("full", false, "Walk the whole history instead of only the pages since the last sync.")
+	syncCmd.Flags().Int("max-pages", 0, "Maximum number of pages of 50 operations to fetch, 0 meaning no limit.")
+}
@@ -0,0 +1,27 @@
+package domain
+
+// BenefitLimits contains the monthly and yearly limits of a benefit. A nil limit means there is none.
+type BenefitLimits struct {
+	Monthly *Money `json:"monthly,omitempty"`
+	Yearly  *Money `json:"yearly,omitempty"`
+}
+
+// Product is a specific product within a benefit category.
+type Product struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Description string `json:"description,omitempty"`
+	Status      string `json:"status"`
+	Type        string `json:"type"`
+}
+
+// Benefit is an employee benefit, such as the meal allowance or health insurance.
+type Benefit struct {
+	ID          string        `json:"id"`
+	Name        string        `json:"name"`
+	Slug        string        `json:"slug"`
+	Description string        `json:"description,omitempty"`
+	Limits      BenefitLimits `json:"limits"`
+	Products    []Product     `json:"products"`
+}
@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+)
+
+// A straight-line projection over a few days or payments is mostly noise, so the projected amount is only
+// flagged as a risk once the period has this much history.
+const (
+	// ProjectionMinDays is the number of days of the period that must have elapsed.
+	ProjectionMinDays = 7
+	// ProjectionMinPayments is the number of payments that must have been made in the period.
+	ProjectionMinPayments = 3
+)
+
+// LimitUtilization is the spending of a benefit over a period against its limit for the period, if any.
+type LimitUtilization struct {
+	Range DateRange `json:"range"`
+	// Used is the amount spent in the period so far, net of refunds.
+	Used Money `json:"used"`
+	// Payments is the number of payments made in the period.
+	Payments int    `json:"payments"`
+	Limit    *Money `json:"limit,omitempty"`
+	// Remaining is what is left of the limit, 0 once it is exceeded.
+	Remaining *Money `json:"remaining,omitempty"`
+	// Percent is the percentage of the limit used.
+	Percent *float64 `json:"percent,omitempty"`
+	// Projected is the amount the period will end with if the spending goes on at the same daily pace.
+	Projected Money `json:"projected"`
+	// Exceeded reports that the limit is already exceeded.
+	Exceeded bool `json:"exceeded"`
+	// ProjectedToExceed reports that the projected amount exceeds the limit, once ProjectionMinDays have
+	// elapsed and ProjectionMinPayments payments were made in the period.
+	ProjectedToExceed bool `json:"projected_to_exceed"`
+}
+
+// BenefitUtilization is the spending of a benefit in the current month and benefit year against its limits.
+type BenefitUtilization struct {
+	Benefit string           `json:"benefit"`
+	Name    string           `json:"name"`
+	Month   LimitUtilization `json:"month"`
+	Year    LimitUtilization `json:"year"`
+	// AtRisk reports that a limit is exceeded or projected to be exceeded.
+	AtRisk bool `json:"at_risk"`
+}
+
+// UtilizationRanges returns the current month and benefit year on the given day. The benefit year is the
+// BenefitPeriod of the renewal date, or the calendar year if it is unknown.
+func UtilizationRanges(today, renewal Date) (DateRange, DateRange) {
+	month, _ := ResolveRelativeRange(RangeCurrentMonth, today, Date{})
+	if renewal.IsZero() {
+		year, _ := ResolveRelativeRange(RangeCurrentYear, today, Date{})
+		return month, year
+	}
+	return month, BenefitPeriod(renewal, today)
+}
+
+// SummarizeBenefitUtilization adds up the operations of each benefit, matched with FindBenefit, in the current
+// month and benefit year. It counts the operations as SummarizeSpending does, so they are expected to be linked
+// with LinkRefunds, and projects the spending to the end of each period at the pace it had until today.
+func SummarizeBenefitUtilization(benefits []Benefit, operations []Operation, today, renewal Date) ([]BenefitUtilization, error) {
+	month, year := UtilizationRanges(today, renewal)
+	utilization := make([]BenefitUtilization, len(benefits))
+	for i, benefit := range benefits {
+		utilization[i] = BenefitUtilization{
+			Benefit: benefit.Slug,
+			Name:    benefit.Name,
+			Month:   LimitUtilization{Range: month, Used: NewMoney(0, DefaultCurrency), Limit: benefit.Limits.Monthly},
+			Year:    LimitUtilization{Range: year, Used: NewMoney(0, DefaultCurrency), Limit: benefit.Limits.Yearly},
+		}
+	}
+
+	for _, operation := range operations {
+		spent, _, payment, ok := spendingOf(operation)
+		if !ok {
+			continue
+		}
+		benefit, _ := FindBenefit(benefits, operation)
+		if benefit == nil {
+			continue
+		}
+		usage := &utilization[benefitIndex(benefits, benefit)]
+		date := operation.ExecutedAt.CalendarDate()
+		for _, period := range []*LimitUtilization{&usage.Month, &usage.Year} {
+			if !period.Range.Contains(date) {
+				continue
+			}
+			used, err := period.Used.Add(spent)
+			if err != nil {
+				return nil, fmt.Errorf("operation %s: %w", operation.ID, err)
+			}
+			period.Used = used
+			if payment {
+				period.Payments++
+			}
+		}
+	}
+
+	for i := range utilization {
+		for _, period := range []*LimitUtilization{&utilization[i].Month, &utilization[i].Year} {
+			if err := period.compare(today); err != nil {
+				return nil, fmt.Errorf("benefit %s: %w", utilization[i].Benefit, err)
+			}
+			utilization[i].AtRisk = utilization[i].AtRisk || period.Exceeded || period.ProjectedToExceed
+		}
+	}
+	return utilization, nil
+}
+
+// compare projects the amount used to the end of the period and compares both with the limit. The projection
+// is only a risk once the period has ProjectionMinDays and ProjectionMinPayments of history.
+func (u *LimitUtilization) compare(today Date) error {
+	elapsed := u.Range.From.DaysUntil(today) + 1
+	length := u.Range.From.DaysUntil(u.Range.To) + 1
+	u.Projected = u.Used
+	if elapsed > 0 && elapsed < length {
+		u.Projected.MinorUnits = roundDiv(u.Used.MinorUnits*int64(length), int64(elapsed))
+	}
+	if u.Limit == nil {
+		return nil
+	}
+
+	remaining, err := u.Limit.Sub(u.Used)
+	if err != nil {
+		return err
+	}
+	if remaining.IsNegative() {
+		remaining.MinorUnits = 0
+		u.Exceeded = true
+	}
+	u.Remaining = &remaining
+	if !u.Limit.IsZero() {
+		percent := math.Round(float64(u.Used.MinorUnits)/float64(u.Limit.MinorUnits)*10000) / 100
+		u.Percent = &percent
+	}
+	projected, err := u.Projected.Cmp(*u.Limit)
+	if err != nil {
+		return err
+	}
+	u.ProjectedToExceed = projected > 0 && elapsed >= ProjectionMinDays && u.Payments >= ProjectionMinPayments
+	return nil
+}
+
+// benefitIndex returns the index of the benefit FindBenefit returned a pointer to.
+func benefitIndex(benefits []Benefit, benefit *Benefit) int {
+	for i := range benefits {
+		if &benefits[i] == benefit {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUtilizationRanges(t *testing.T) {
+	today := NewDate(2025, time.March, 10)
+	tests := []struct {
+		name    string
+		renewal Date
+		year    DateRange
+	}{
+		{"renewal later in the year", NewDate(2023, time.September, 1), DateRange{From: NewDate(2024, time.September, 1), To: NewDate(2025, time.August, 31)}},
+		{"renewal today", NewDate(2024, time.March, 10), DateRange{From: NewDate(2025, time.March, 10), To: NewDate(2026, time.March, 9)}},
+		{"unknown renewal is the calendar year", Date{}, DateRange{From: NewDate(2025, time.January, 1), To: NewDate(2025, time.December, 31)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			month, year := UtilizationRanges(today, tt.renewal)
+			if month != (DateRange{From: NewDate(2025, time.March, 1), To: NewDate(2025, time.March, 31)}) {
+				t.Errorf("month = %s..%s, want March 2025", month.From, month.To)
+			}
+			if year != tt.year {
+				t.Errorf("year = %s..%s, want %s..%s", year.From, year.To, tt.year.From, tt.year.To)
+			}
+		})
+	}
+}
+
+func TestSummarizeBenefitUtilizationProjection(t *testing.T) {
+	limit := NewMoney(10000, DefaultCurrency)
+	benefits := []Benefit{{Slug: string(CategoryMeal), Name: "Meal", Limits: BenefitLimits{Monthly: &limit}}}
+	// payments returns a meal payment of the given cents on each of the first days of March.
+	payments := func(cents ...int64) []Operation {
+		operations := make([]Operation, len(cents))
+		for i, amount := range cents {
+			operations[i] = Operation{ID: string(rune('a' + i)), Amount: NewMoney(-amount, DefaultCurrency), IsDebit: true,
+				ExecutedAt:   NewTimestamp(time.Date(2025, time.March, i+1, 12, 0, 0, 0, time.UTC)),
+				CategorySlug: CategoryMeal, Type: OperationTypeCardTransaction}
+		}
+		return operations
+	}
+
+	tests := []struct {
+		name       string
+		operations []Operation
+		today      int
+		projected  int64
+		remaining  int64
+		percent    float64
+		exceeded   bool
+		toExceed   bool
+	}{
+		{
+			name:       "too few days to trust the pace",
+			operations: payments(2000, 2000, 2000),
+			today:      ProjectionMinDays - 1,
+			projected:  31000,
+			remaining:  4000,
+			percent:    60,
+		},
+		{
+			name:       "too few payments to trust the pace",
+			operations: payments(4000, 4000),
+			today:      10,
+			projected:  24800,
+			remaining:  2000,
+			percent:    80,
+		},
+		{
+			name:       "enough days and payments",
+			operations: payments(3000, 3000, 3000),
+			today:      10,
+			projected:  27900,
+			remaining:  1000,
+			percent:    90,
+			toExceed:   true,
+		},
+		{
+			name:       "an exceeded limit is a risk from the first day",
+			operations: payments(12000),
+			today:      1,
+			projected:  372000,
+			remaining:  0,
+			percent:    120,
+			exceeded:   true,
+		},
+		{
+			name:       "on the last day the projection is what was used",
+			operations: payments(3000, 3000, 3000),
+			today:      31,
+			projected:  9000,
+			remaining:  1000,
+			percent:    90,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			utilization, err := SummarizeBenefitUtilization(benefits, LinkRefunds(tt.operations), NewDate(2025, time.March, tt.today), Date{})
+			if err != nil {
+				t.Fatalf("SummarizeBenefitUtilization() error = %v", err)
+			}
+			month := utilization[0].Month
+			if month.Payments != len(tt.operations) {
+				t.Errorf("payments = %d, want %d", month.Payments, len(tt.operations))
+			}
+			if month.Projected.MinorUnits != tt.projected || month.Remaining.MinorUnits != tt.remaining || *month.Percent != tt.percent {
+				t.Errorf("projected, remaining, percent = %d, %d, %v, want %d, %d, %v",
+					month.Projected.MinorUnits, month.Remaining.MinorUnits, *month.Percent, tt.projected, tt.remaining, tt.percent)
+			}
+			if month.Exceeded != tt.exceeded || month.ProjectedToExceed != tt.toExceed {
+				t.Errorf("exceeded, projected to exceed = %v, %v, want %v, %v", month.Exceeded, month.ProjectedToExceed, tt.exceeded, tt.toExceed)
+			}
+			if atRisk := tt.exceeded || tt.toExceed; utilization[0].AtRisk != atRisk {
+				t.Errorf("at risk = %v, want %v", utilization[0].AtRisk, atRisk)
+			}
+			if year := utilization[0].Year; year.Limit != nil || year.Remaining != nil || year.ProjectedToExceed {
+				t.Errorf("year = %+v, want no limit to compare with", year)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package domain
+
+// Card is an employee payment card.
+type Card struct {
+	ID                 string     `json:"id"`
+	ActivatedAt        *Timestamp `json:"activated_at,omitempty"`
+	ExpirationDate     Date       `json:"expiration_date"`
+	Format             string     `json:"format"`
+	HolderCompanyName  string     `json:"holder_company_name"`
+	HolderName         string     `json:"holder_name"`
+	IsExpiring         bool       `json:"is_expiring"`
+	IsPlasticRequested bool       `json:"is_plastic_requested"`
+	Network            string     `json:"network"`
+	OwnerID            string     `json:"owner_id"`
+	PANLastDigits      string     `json:"pan_last_digits"`
+	ProviderID         string     `json:"provider_id"`
+	Status             string     `json:"status"`
+	Version            string     `json:"version"`
+}
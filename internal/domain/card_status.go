@@ -0,0 +1,222 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+)
+
+// CardExpiryWarningDays is how many days before its expiration a card is considered to be expiring soon.
+const CardExpiryWarningDays = 60
+
+// Known card formats and statuses. Values outside the known set are kept as they are.
+const (
+	CardFormatVirtual = "virtual"
+	CardFormatPlastic = "plastic"
+
+	CardStatusActive    = "active"
+	CardStatusInactive  = "inactive"
+	CardStatusBlocked   = "blocked"
+	CardStatusCancelled = "cancelled"
+)
+
+// IsVirtual reports whether the card only exists as a virtual card.
+func (c Card) IsVirtual() bool {
+	return strings.EqualFold(c.Format, CardFormatVirtual)
+}
+
+// IsPlastic reports whether the card is a physical card.
+func (c Card) IsPlastic() bool {
+	return strings.EqualFold(c.Format, CardFormatPlastic) || strings.EqualFold(c.Format, "physical")
+}
+
+// IsActive reports whether the card can be used for payments.
+func (c Card) IsActive() bool {
+	return strings.EqualFold(c.Status, CardStatusActive)
+}
+
+// IsBlocked reports whether the card is blocked. Unlike a closed card, a blocked card can usually be unblocked.
+func (c Card) IsBlocked() bool {
+	return strings.EqualFold(c.Status, CardStatusBlocked)
+}
+
+// IsClosed reports whether the card was cancelled or terminated, so it can no longer be used or activated.
+func (c Card) IsClosed() bool {
+	return slices.ContainsFunc([]string{CardStatusCancelled, "canceled", "terminated"}, func(status string) bool {
+		return strings.EqualFold(c.Status, status)
+	})
+}
+
+// DaysToExpiry returns the days left from today until the card expires, negative once expired.
+// It returns false if the card has no expiration date.
+func (c Card) DaysToExpiry(today Date) (int, bool) {
+	if c.ExpirationDate.IsZero() {
+		return 0, false
+	}
+	return today.DaysUntil(c.ExpirationDate), true
+}
+
+// CardRequestPolicy is what the company allows employees to do with their cards, taken from its settings.
+type CardRequestPolicy struct {
+	// EmployeeCanRequest reports whether employees may request cards themselves.
+	EmployeeCanRequest bool `json:"employee_can_request"`
+	// Format is the format of the cards that can be requested, e.g. "virtual" or "plastic".
+	Format string `json:"format,omitempty"`
+	// Automatic reports whether the company issues and replaces cards without the employee asking.
+	Automatic bool `json:"automatic"`
+	// ShippingStrategy is where physical cards are shipped to, e.g. the company or the employee address.
+	ShippingStrategy string `json:"shipping_strategy,omitempty"`
+}
+
+// NewCardRequestPolicy derives the card request policy from the company settings.
+func NewCardRequestPolicy(settings CompanySettings) CardRequestPolicy {
+	permission := strings.ToLower(settings.CardRequestEmployeePermission)
+	return CardRequestPolicy{
+		EmployeeCanRequest: permission != "" && !slices.Contains([]string{"not_allowed", "none", "disabled", "forbidden", "denied"}, permission),
+		Format:             settings.CardRequestFormat,
+		Automatic:          strings.Contains(strings.ToLower(settings.CardRequestStrategy), "automatic"),
+		ShippingStrategy:   settings.CardShippingStrategy,
+	}
+}
+
+// AllowsPlastic reports whether physical cards can be requested. An unset format does not restrict it.
+func (p CardRequestPolicy) AllowsPlastic() bool {
+	format := strings.ToLower(p.Format)
+	return format == "" || strings.Contains(format, CardFormatPlastic) || strings.Contains(format, "physical")
+}
+
+// CardAction is something the user can do next with a card.
+type CardAction string
+
+// Card actions suggested by AssessCards.
+const (
+	CardActionActivate                  CardAction = "activate_card"
+	CardActionUnblock                   CardAction = "unblock_card"
+	CardActionRequestPlastic            CardAction = "request_physical_card"
+	CardActionAwaitPlastic              CardAction = "await_physical_card_delivery"
+	CardActionRequestReplacement        CardAction = "request_replacement"
+	CardActionAwaitAutomaticReplacement CardAction = "await_automatic_replacement"
+	CardActionAskHR                     CardAction = "ask_hr"
+	CardActionContactSupport            CardAction = "contact_support"
+)
+
+// CardReason identifies why a card action is suggested. It is rendered into the reason text in the
+// language of the request.
+type CardReason string
+
+// Card action reasons given by AssessCards.
+const (
+	CardReasonClosed                       CardReason = "card_closed"
+	CardReasonBlocked                      CardReason = "card_blocked"
+	CardReasonNotActivated                 CardReason = "card_not_activated"
+	CardReasonPlasticRequested             CardReason = "physical_card_requested"
+	CardReasonNoPlasticPolicyUnknown       CardReason = "no_physical_card_policy_unknown"
+	CardReasonNoPlasticCanRequest          CardReason = "no_physical_card_can_request"
+	CardReasonNoPlasticCannotRequest       CardReason = "no_physical_card_cannot_request"
+	CardReasonExpiredPolicyUnknown         CardReason = "card_expired_policy_unknown"
+	CardReasonExpiredAutomaticReplacement  CardReason = "card_expired_automatic_replacement"
+	CardReasonExpiredCanRequest            CardReason = "card_expired_can_request"
+	CardReasonExpiredCannotRequest         CardReason = "card_expired_cannot_request"
+	CardReasonExpiringPolicyUnknown        CardReason = "card_expiring_policy_unknown"
+	CardReasonExpiringAutomaticReplacement CardReason = "card_expiring_automatic_replacement"
+	CardReasonExpiringCanRequest           CardReason = "card_expiring_can_request"
+	CardReasonExpiringCannotRequest        CardReason = "card_expiring_cannot_request"
+)
+
+// CardAdvice is a suggested action together with the reason it is suggested.
+type CardAdvice struct {
+	Action     CardAction `json:"action"`
+	ReasonCode CardReason `json:"reason_code"`
+	// Reason is the reason rendered in the language of the request.
+	Reason string `json:"reason"`
+}
+
+// CardStatus is a card with the insights derived from its lifecycle.
+type CardStatus struct {
+	Card Card `json:"card"`
+	// DaysToExpiry is nil when the card has no expiration date.
+	DaysToExpiry *int `json:"days_to_expiry,omitempty"`
+	Expired      bool `json:"expired"`
+	ExpiringSoon bool `json:"expiring_soon"`
+	// Blocked reports whether the card is blocked, so it cannot be used until it is unblocked.
+	Blocked bool `json:"blocked"`
+	// LacksPhysicalCard reports whether the card is virtual and there is no physical card, issued or requested.
+	LacksPhysicalCard bool `json:"lacks_physical_card"`
+	// NeedsActivation reports whether the card was issued but was never activated, so it must be activated
+	// before it can be used.
+	NeedsActivation bool         `json:"needs_activation"`
+	NextActions     []CardAdvice `json:"next_actions"`
+}
+
+// AssessCards computes the status of every card on the given day. The policy is nil when the company
+// settings are unknown, in which case the actions that depend on them are left to HR.
+func AssessCards(cards []Card, policy *CardRequestPolicy, today Date) []CardStatus {
+	hasPlastic := slices.ContainsFunc(cards, func(card Card) bool {
+		return card.IsPlastic() && !card.IsClosed() || card.IsPlasticRequested
+	})
+
+	statuses := make([]CardStatus, 0, len(cards))
+	for _, card := range cards {
+		status := CardStatus{
+			Card:              card,
+			ExpiringSoon:      card.IsExpiring,
+			LacksPhysicalCard: card.IsVirtual() && !hasPlastic,
+			Blocked:           card.IsBlocked(),
+		}
+		if days, ok := card.DaysToExpiry(today); ok {
+			status.DaysToExpiry = &days
+			status.Expired = days < 0
+			status.ExpiringSoon = status.ExpiringSoon || days <= CardExpiryWarningDays
+		}
+		status.NeedsActivation = card.ActivatedAt == nil && !card.IsClosed() && !status.Expired
+		status.NextActions = adviseCard(card, status, policy)
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+func adviseCard(card Card, status CardStatus, policy *CardRequestPolicy) []CardAdvice {
+	advice := []CardAdvice{}
+	if card.IsClosed() {
+		return append(advice, CardAdvice{Action: CardActionContactSupport, ReasonCode: CardReasonClosed})
+	}
+	if status.Blocked {
+		advice = append(advice, CardAdvice{Action: CardActionUnblock, ReasonCode: CardReasonBlocked})
+	}
+	if status.NeedsActivation {
+		advice = append(advice, CardAdvice{Action: CardActionActivate, ReasonCode: CardReasonNotActivated})
+	}
+	if card.IsPlasticRequested && card.IsVirtual() {
+		advice = append(advice, CardAdvice{Action: CardActionAwaitPlastic, ReasonCode: CardReasonPlasticRequested})
+	}
+
+	if status.LacksPhysicalCard {
+		switch {
+		case policy == nil:
+			advice = append(advice, CardAdvice{Action: CardActionAskHR, ReasonCode: CardReasonNoPlasticPolicyUnknown})
+		case policy.EmployeeCanRequest && policy.AllowsPlastic():
+			advice = append(advice, CardAdvice{Action: CardActionRequestPlastic, ReasonCode: CardReasonNoPlasticCanRequest})
+		default:
+			advice = append(advice, CardAdvice{Action: CardActionAskHR, ReasonCode: CardReasonNoPlasticCannotRequest})
+		}
+	}
+
+	if status.Expired || status.ExpiringSoon {
+		reason := func(expired, expiring CardReason) CardReason {
+			if status.Expired {
+				return expired
+			}
+			return expiring
+		}
+		switch {
+		case policy == nil:
+			advice = append(advice, CardAdvice{Action: CardActionAskHR, ReasonCode: reason(CardReasonExpiredPolicyUnknown, CardReasonExpiringPolicyUnknown)})
+		case policy.Automatic:
+			advice = append(advice, CardAdvice{Action: CardActionAwaitAutomaticReplacement, ReasonCode: reason(CardReasonExpiredAutomaticReplacement, CardReasonExpiringAutomaticReplacement)})
+		case policy.EmployeeCanRequest:
+			advice = append(advice, CardAdvice{Action: CardActionRequestReplacement, ReasonCode: reason(CardReasonExpiredCanRequest, CardReasonExpiringCanRequest)})
+		default:
+			advice = append(advice, CardAdvice{Action: CardActionAskHR, ReasonCode: reason(CardReasonExpiredCannotRequest, CardReasonExpiringCannotRequest)})
+		}
+	}
+	return advice
+}
@@ -0,0 +1,50 @@
+package domain
+
+// Address is a company address.
+type Address struct {
+	AddressLine1 string `json:"address_line_1"`
+	AddressLine2 string `json:"address_line_2,omitempty"`
+	City         string `json:"city"`
+	Country      string `json:"country"`
+	District     string `json:"district"`
+	Type         string `json:"type"`
+	Zipcode      string `json:"zipcode"`
+}
+
+// Market is the market a company operates in.
+type Market struct {
+	Languages []string `json:"languages"`
+	Slug      string   `json:"slug"`
+}
+
+// CompanySettings are the policies the company configured for its employees.
+type CompanySettings struct {
+	CardRequestEmployeePermission  string `json:"card_request_employee_permission"`
+	CardRequestFormat              string `json:"card_request_format"`
+	CardRequestStrategy            string `json:"card_request_strategy"`
+	CardShippingStrategy           string `json:"card_shipping_strategy"`
+	IncludeEmployeeNumberInReports bool   `json:"include_employee_number_in_reports"`
+	KinshipDegreeProofRequired     bool   `json:"kinship_degree_proof_required"`
+	Plan                           string `json:"plan"`
+	SavingsEmployeeEnabled         bool   `json:"savings_employee_enabled"`
+}
+
+// TaxID is the tax identification of a company.
+type TaxID struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Company is the employer of the user.
+type Company struct {
+	ID                string          `json:"id"`
+	Name              string          `json:"name"`
+	LegalName         string          `json:"legal_name"`
+	CardDisplayName   string          `json:"card_display_name"`
+	LogoURI           string          `json:"logo_uri,omitempty"`
+	Addresses         []Address       `json:"addresses"`
+	Market            Market          `json:"market"`
+	Settings          CompanySettings `json:"settings"`
+	TaxID             TaxID           `json:"tax_id"`
+	HasSocialBenefits bool            `json:"has_social_benefits"`
+}
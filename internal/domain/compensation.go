@@ -0,0 +1,23 @@
+package domain
+
+// Attribution is the balance of a type of compensation attribution.
+type Attribution struct {
+	ID      string `json:"id"`
+	Slug    string `json:"slug"`
+	Balance Money  `json:"balance"`
+}
+
+// CompensationBenefit is the balance of a benefit within the compensation summary.
+type CompensationBenefit struct {
+	Slug    string `json:"slug"`
+	Balance Money  `json:"balance"`
+}
+
+// Compensation is the summary of the employee compensation for the current benefit period.
+type Compensation struct {
+	Attributions []Attribution         `json:"attributions"`
+	Benefits     []CompensationBenefit `json:"benefits"`
+	// RenewalDate is the day the benefit period renews.
+	RenewalDate Date   `json:"renewal_date"`
+	Status      string `json:"status"`
+}
@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// DateRange is a range of calendar days, both ends inclusive. A zero end leaves that side unbounded.
+type DateRange struct {
+	From Date `json:"from"`
+	To   Date `json:"to"`
+}
+
+// IsZero reports whether the range is unbounded on both sides.
+func (r DateRange) IsZero() bool {
+	return r.From.IsZero() && r.To.IsZero()
+}
+
+// Contains reports whether the day is within the range.
+func (r DateRange) Contains(d Date) bool {
+	return (r.From.IsZero() || !d.Before(r.From)) && (r.To.IsZero() || !d.After(r.To))
+}
+
+// Validate fails if the range ends before it starts.
+func (r DateRange) Validate() error {
+	if !r.From.IsZero() && !r.To.IsZero() && r.To.Before(r.From) {
+		return fmt.Errorf("invalid date range: %s is after %s", r.From, r.To)
+	}
+	return nil
+}
+
+// ParseDateRange parses the bounds of a range, either of which may be empty. Besides the formats accepted
+// by ParseDate, a month such as "2026-03" or "03/2026" means its first day as the start and its last day
+// as the end.
+func ParseDateRange(from, to string) (DateRange, error) {
+	var r DateRange
+	if from != "" {
+		start, err := parseRangeBound(from, false)
+		if err != nil {
+			return DateRange{}, err
+		}
+		r.From = start
+	}
+	if to != "" {
+		end, err := parseRangeBound(to, true)
+		if err != nil {
+			return DateRange{}, err
+		}
+		r.To = end
+	}
+	return r, r.Validate()
+}
+
+// monthLayouts are the formats of a whole month accepted as a range bound.
+var monthLayouts = []string{"2006-01", "01/2006"}
+
+// parseRangeBound parses a bound of a range, where a month means its first day, or its last day for the end.
+func parseRangeBound(value string, end bool) (Date, error) {
+	for _, layout := range monthLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			if end {
+				t = t.AddDate(0, 1, -1)
+			}
+			return DateOf(t), nil
+		}
+	}
+	date, err := ParseDate(value)
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD or a month as YYYY-MM", value)
+	}
+	return date, nil
+}
+
+// Relative date ranges accepted by ResolveRelativeRange.
+const (
+	RangeToday                = "today"
+	RangeYesterday            = "yesterday"
+	RangeLast7Days            = "last_7_days"
+	RangeLast30Days           = "last_30_days"
+	RangeLast90Days           = "last_90_days"
+	RangeCurrentMonth         = "current_month"
+	RangeLastMonth            = "last_month"
+	RangeCurrentYear          = "current_year"
+	RangeLastYear             = "last_year"
+	RangeCurrentBenefitPeriod = "current_benefit_period"
+	RangeLastBenefitPeriod    = "last_benefit_period"
+)
+
+// RelativeRanges lists the relative date ranges, in the order they are offered.
+var RelativeRanges = []string{
+	RangeToday,
+	RangeYesterday,
+	RangeLast7Days,
+	RangeLast30Days,
+	RangeLast90Days,
+	RangeCurrentMonth,
+	RangeLastMonth,
+	RangeCurrentYear,
+	RangeLastYear,
+	RangeCurrentBenefitPeriod,
+	RangeLastBenefitPeriod,
+}
+
+// IsBenefitPeriodRange reports whether resolving the relative range needs the benefit renewal date.
+func IsBenefitPeriodRange(name string) bool {
+	return name == RangeCurrentBenefitPeriod || name == RangeLastBenefitPeriod
+}
+
+// ResolveRelativeRange returns the days covered by a relative range on the given day. The renewal date
+// is the one reported in the compensation summary, and is only needed for the benefit period ranges.
+func ResolveRelativeRange(name string, today, renewal Date) (DateRange, error) {
+	firstOfMonth := NewDate(today.Year, today.Month, 1)
+	switch name {
+	case RangeToday:
+		return DateRange{From: today, To: today}, nil
+	case RangeYesterday:
+		yesterday := today.AddDate(0, 0, -1)
+		return DateRange{From: yesterday, To: yesterday}, nil
+	case RangeLast7Days:
+		return DateRange{From: today.AddDate(0, 0, -6), To: today}, nil
+	case RangeLast30Days:
+		return DateRange{From: today.AddDate(0, 0, -29), To: today}, nil
+	case RangeLast90Days:
+		return DateRange{From: today.AddDate(0, 0, -89), To: today}, nil
+	case RangeCurrentMonth:
+		return DateRange{From: firstOfMonth, To: firstOfMonth.AddDate(0, 1, -1)}, nil
+	case RangeLastMonth:
+		return DateRange{From: firstOfMonth.AddDate(0, -1, 0), To: firstOfMonth.AddDate(0, 0, -1)}, nil
+	case RangeCurrentYear:
+		return DateRange{From: NewDate(today.Year, time.January, 1), To: NewDate(today.Year, time.December, 31)}, nil
+	case RangeLastYear:
+		return DateRange{From: NewDate(today.Year-1, time.January, 1), To: NewDate(today.Year-1, time.December, 31)}, nil
+	case RangeCurrentBenefitPeriod, RangeLastBenefitPeriod:
+		if renewal.IsZero() {
+			return DateRange{}, fmt.Errorf("the benefit renewal date is unknown")
+		}
+		period := BenefitPeriod(renewal, today)
+		if name == RangeLastBenefitPeriod {
+			period = DateRange{From: period.From.AddDate(-1, 0, 0), To: period.From.AddDate(0, 0, -1)}
+		}
+		return period, nil
+	default:
+		return DateRange{}, fmt.Errorf("unknown date range %q", name)
+	}
+}
+
+// BenefitPeriod returns the yearly benefit period that contains today, which ends the day before the
+// benefits renew. The renewal date is moved a year at a time until it is the first one after today.
+func BenefitPeriod(renewal, today Date) DateRange {
+	for !renewal.After(today) {
+		renewal = renewal.AddDate(1, 0, 0)
+	}
+	for renewal.AddDate(-1, 0, 0).After(today) {
+		renewal = renewal.AddDate(-1, 0, 0)
+	}
+	return DateRange{From: renewal.AddDate(-1, 0, 0), To: renewal.AddDate(0, 0, -1)}
+}
@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata" // The Europe/Lisbon display timezone must not depend on the host.
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Date
+		wantErr bool
+	}{
+		{value: "2025-03-10", want: NewDate(2025, time.March, 10)},
+		{value: "2024-02-29", want: NewDate(2024, time.February, 29)},
+		// Late in the UTC evening of a summer day is already the next day in Lisbon.
+		{value: "2025-07-31T23:30:00Z", want: NewDate(2025, time.August, 1)},
+		{value: "2025-03", wantErr: true},
+		{value: "03/25", wantErr: true},
+		{value: "03/2025", wantErr: true},
+		{value: "2025-02-30", wantErr: true},
+		{value: "yesterday", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseDate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDate(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDate(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDateRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		want     DateRange
+		wantErr  bool
+	}{
+		{
+			name: "days",
+			from: "2025-03-10", to: "2025-03-20",
+			want: DateRange{From: NewDate(2025, time.March, 10), To: NewDate(2025, time.March, 20)},
+		},
+		{
+			name: "a month starts on its first day and ends on its last",
+			from: "2025-02", to: "2025-02",
+			want: DateRange{From: NewDate(2025, time.February, 1), To: NewDate(2025, time.February, 28)},
+		},
+		{
+			name: "slashed months",
+			from: "02/2024", to: "02/2024",
+			want: DateRange{From: NewDate(2024, time.February, 1), To: NewDate(2024, time.February, 29)},
+		},
+		{
+			name: "open start",
+			to:   "2025-03",
+			want: DateRange{To: NewDate(2025, time.March, 31)},
+		},
+		{
+			name: "open end",
+			from: "2025-03",
+			want: DateRange{From: NewDate(2025, time.March, 1)},
+		},
+		{name: "two-digit years are ambiguous", from: "03/25", wantErr: true},
+		{name: "end before start", from: "2025-03-10", to: "2025-03-09", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDateRange(tt.from, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDateRange(%q, %q) error = %v, wantErr %v", tt.from, tt.to, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseDateRange(%q, %q) = %+v, want %+v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveRelativeRange(t *testing.T) {
+	today := NewDate(2025, time.March, 1)
+	renewal := NewDate(2019, time.September, 1)
+	tests := []struct {
+		name     string
+		from, to Date
+	}{
+		{RangeToday, today, today},
+		{RangeYesterday, NewDate(2025, time.February, 28), NewDate(2025, time.February, 28)},
+		{RangeLast7Days, NewDate(2025, time.February, 23), today},
+		{RangeCurrentMonth, today, NewDate(2025, time.March, 31)},
+		{RangeLastMonth, NewDate(2025, time.February, 1), NewDate(2025, time.February, 28)},
+		{RangeLastYear, NewDate(2024, time.January, 1), NewDate(2024, time.December, 31)},
+		{RangeCurrentBenefitPeriod, NewDate(2024, time.September, 1), NewDate(2025, time.August, 31)},
+		{RangeLastBenefitPeriod, NewDate(2023, time.September, 1), NewDate(2024, time.August, 31)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveRelativeRange(tt.name, today, renewal)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.From != tt.from || got.To != tt.to {
+				t.Errorf("ResolveRelativeRange(%s) = [%s, %s], want [%s, %s]", tt.name, got.From, got.To, tt.from, tt.to)
+			}
+		})
+	}
+
+	if _, err := ResolveRelativeRange(RangeCurrentBenefitPeriod, today, Date{}); err == nil {
+		t.Error("ResolveRelativeRange without a renewal date succeeded, want an error")
+	}
+}
+
+func TestBenefitPeriod(t *testing.T) {
+	renewal := NewDate(2025, time.September, 1)
+	tests := []struct {
+		name     string
+		today    Date
+		from, to Date
+	}{
+		{"before the renewal", NewDate(2025, time.March, 15), NewDate(2024, time.September, 1), NewDate(2025, time.August, 31)},
+		{"on the renewal day", renewal, renewal, NewDate(2026, time.August, 31)},
+		{"the day before the renewal", NewDate(2025, time.August, 31), NewDate(2024, time.September, 1), NewDate(2025, time.August, 31)},
+		{"years after the renewal", NewDate(2029, time.January, 10), NewDate(2028, time.September, 1), NewDate(2029, time.August, 31)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BenefitPeriod(renewal, tt.today)
+			if got.From != tt.from || got.To != tt.to {
+				t.Errorf("BenefitPeriod(%s) = [%s, %s], want [%s, %s]", tt.today, got.From, got.To, tt.from, tt.to)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package domain
+
+// FamilyMember is a member of the employee household.
+type FamilyMember struct {
+	ID           string `json:"id"`
+	FullName     string `json:"full_name"`
+	ShortName    string `json:"short_name"`
+	BirthDate    Date   `json:"birth_date"`
+	RelationType string `json:"relation_type"`
+	Gender       string `json:"gender"`
+}
@@ -0,0 +1,215 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+)
+
+// DependantMaxAge is the last age at which a child still counts as a dependant.
+const DependantMaxAge = 25
+
+// DefaultEligibilityHorizonDays is how far ahead AssessFamily looks for birthdays that change eligibility.
+const DefaultEligibilityHorizonDays = 365
+
+// childRelations are the relation types of the members that are children of the employee.
+var childRelations = []string{"child", "son", "daughter", "stepchild", "dependant", "dependent"}
+
+// IsChild reports whether the member is a child of the employee.
+func (m FamilyMember) IsChild() bool {
+	return slices.Contains(childRelations, strings.ToLower(m.RelationType))
+}
+
+// Age returns the age of the member on the given day. It returns false if the birth date is unknown.
+func (m FamilyMember) Age(today Date) (int, bool) {
+	if m.BirthDate.IsZero() {
+		return 0, false
+	}
+	age := today.Year - m.BirthDate.Year
+	if today.Month < m.BirthDate.Month || today.Month == m.BirthDate.Month && today.Day < m.BirthDate.Day {
+		age--
+	}
+	return age, true
+}
+
+// IsDependant reports whether the member is a dependant of the employee on the given day:
+// a child up to DependantMaxAge, or of unknown age.
+func (m FamilyMember) IsDependant(today Date) bool {
+	if !m.IsChild() {
+		return false
+	}
+	age, ok := m.Age(today)
+	return !ok || age <= DependantMaxAge
+}
+
+// BirthdayAt returns the day the member turns the given age.
+func (m FamilyMember) BirthdayAt(age int) Date {
+	return m.BirthDate.AddDate(age, 0, 0)
+}
+
+// EligibilityRule describes which family members a benefit category applies to.
+type EligibilityRule struct {
+	Category CategorySlug
+	// ChildrenOnly restricts the rule to the children of the employee.
+	ChildrenOnly bool
+	// MinAge and MaxAge bound the ages the rule applies to, both inclusive. A negative MaxAge means no limit.
+	MinAge int
+	MaxAge int
+}
+
+// EligibilityRules are the family related rules of the benefit categories, following the Portuguese
+// tax-exempt benefit vouchers, and can be replaced to follow another plan:
+//   - nursery: the "vale infância" of Decreto-Lei n.º 26/99 covers children under 7 years old.
+//   - education: the "vale educação" of article 2.º-A, n.º 1, b) of the Código do IRS covers dependants
+//     aged 7 to 25.
+//   - health and insurance: the household, as health expenses and insurance can cover the family members.
+var EligibilityRules = []EligibilityRule{
+	{Category: CategoryNursery, ChildrenOnly: true, MinAge: 0, MaxAge: 6},
+	{Category: CategoryEducation, ChildrenOnly: true, MinAge: 7, MaxAge: DependantMaxAge},
+	{Category: CategoryHealth, MinAge: 0, MaxAge: -1},
+	{Category: CategoryInsurance, MinAge: 0, MaxAge: -1},
+}
+
+func (r EligibilityRule) appliesTo(member FamilyMember, age int) bool {
+	if r.ChildrenOnly && !member.IsChild() {
+		return false
+	}
+	return age >= r.MinAge && (r.MaxAge < 0 || age <= r.MaxAge)
+}
+
+// EligibleBenefit is a benefit of the employee that applies to a family member.
+type EligibleBenefit struct {
+	Category    CategorySlug `json:"category"`
+	BenefitSlug string       `json:"benefit_slug"`
+	BenefitName string       `json:"benefit_name"`
+	// Products are the slugs of the benefit products.
+	Products []string `json:"products,omitempty"`
+	// Reason is why the category applies to the member, in the language of the request.
+	Reason string `json:"reason"`
+}
+
+// Eligibility changes reported by AssessFamily.
+const (
+	EligibilityGained = "becomes_eligible"
+	EligibilityLost   = "ages_out"
+)
+
+// EligibilityChange is an upcoming birthday that changes which benefit categories apply to a family member.
+type EligibilityChange struct {
+	Date      Date         `json:"date"`
+	DaysUntil int          `json:"days_until"`
+	Age       int          `json:"age"`
+	Category  CategorySlug `json:"category"`
+	Change    string       `json:"change"`
+	// Offered reports whether the employee currently has a benefit of the category.
+	Offered bool `json:"offered"`
+}
+
+// FamilyMemberInsight is a family member with the insights derived from their age and relation.
+type FamilyMemberInsight struct {
+	Member FamilyMember `json:"member"`
+	// Age is nil when the birth date is unknown.
+	Age                *int                `json:"age,omitempty"`
+	IsDependant        bool                `json:"is_dependant"`
+	NextBirthday       *Date               `json:"next_birthday,omitempty"`
+	DaysToNextBirthday *int                `json:"days_to_next_birthday,omitempty"`
+	EligibleBenefits   []EligibleBenefit   `json:"eligible_benefits"`
+	EligibilityChanges []EligibilityChange `json:"eligibility_changes"`
+}
+
+// AssessFamily computes the insights of every family member on the given day, cross-referencing them
+// with the employee benefits. Eligibility changes are reported up to horizonDays ahead.
+func AssessFamily(members []FamilyMember, benefits []Benefit, today Date, horizonDays int) []FamilyMemberInsight {
+	insights := make([]FamilyMemberInsight, 0, len(members))
+	for _, member := range members {
+		insight := FamilyMemberInsight{
+			Member:             member,
+			IsDependant:        member.IsDependant(today),
+			EligibleBenefits:   []EligibleBenefit{},
+			EligibilityChanges: []EligibilityChange{},
+		}
+
+		age, ok := member.Age(today)
+		if !ok {
+			insights = append(insights, insight)
+			continue
+		}
+		insight.Age = &age
+		nextBirthday := member.BirthdayAt(age + 1)
+		daysToNextBirthday := today.DaysUntil(nextBirthday)
+		insight.NextBirthday = &nextBirthday
+		insight.DaysToNextBirthday = &daysToNextBirthday
+
+		for _, rule := range EligibilityRules {
+			offered := benefitsOfCategory(benefits, rule.Category)
+			if rule.appliesTo(member, age) {
+				for _, benefit := range offered {
+					insight.EligibleBenefits = append(insight.EligibleBenefits, EligibleBenefit{
+						Category:    rule.Category,
+						BenefitSlug: benefit.Slug,
+						BenefitName: benefit.Name,
+						Products:    productSlugs(benefit),
+					})
+				}
+			}
+			insight.EligibilityChanges = append(insight.EligibilityChanges,
+				eligibilityChanges(member, rule, today, horizonDays, len(offered) > 0)...)
+		}
+		slices.SortFunc(insight.EligibilityChanges, func(a, b EligibilityChange) int {
+			return a.Date.Compare(b.Date)
+		})
+		insights = append(insights, insight)
+	}
+	return insights
+}
+
+// eligibilityChanges returns the birthdays within the horizon on which the member enters or leaves the rule.
+func eligibilityChanges(member FamilyMember, rule EligibilityRule, today Date, horizonDays int, offered bool) []EligibilityChange {
+	if rule.ChildrenOnly && !member.IsChild() {
+		return nil
+	}
+	candidates := map[int]string{rule.MinAge: EligibilityGained}
+	if rule.MaxAge >= 0 {
+		candidates[rule.MaxAge+1] = EligibilityLost
+	}
+
+	var changes []EligibilityChange
+	for age, change := range candidates {
+		if age == 0 {
+			continue
+		}
+		birthday := member.BirthdayAt(age)
+		days := today.DaysUntil(birthday)
+		if days <= 0 || days > horizonDays {
+			continue
+		}
+		changes = append(changes, EligibilityChange{
+			Date:      birthday,
+			DaysUntil: days,
+			Age:       age,
+			Category:  rule.Category,
+			Change:    change,
+			Offered:   offered,
+		})
+	}
+	return changes
+}
+
+// benefitsOfCategory returns the benefits of the given category. The API gives products no category of
+// their own: they belong to the category of their benefit, which is its slug.
+func benefitsOfCategory(benefits []Benefit, category CategorySlug) []Benefit {
+	var matching []Benefit
+	for _, benefit := range benefits {
+		if CategorySlug(benefit.Slug) == category {
+			matching = append(matching, benefit)
+		}
+	}
+	return matching
+}
+
+func productSlugs(benefit Benefit) []string {
+	slugs := make([]string, 0, len(benefit.Products))
+	for _, product := range benefit.Products {
+		slugs = append(slugs, product.Slug)
+	}
+	return slugs
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "context"
+
+type languageKey struct{}
+
+// WithLanguage returns a context whose content is requested in the given language, as an ISO 639-1 code or
+// locale, overriding the configured one. An empty language leaves the context unchanged.
+func WithLanguage(ctx context.Context, language string) context.Context {
+	if language == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, languageKey{}, language)
+}
+
+// LanguageFrom returns the language set in the context with WithLanguage, if any.
+func LanguageFrom(ctx context.Context) (string, bool) {
+	language, ok := ctx.Value(languageKey{}).(string)
+	return language, ok
+}
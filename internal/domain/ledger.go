@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"time"
+)
+
+// StatusTransition is a change detected on an operation already in the ledger, such as a pending
+// operation being confirmed or a payment being reversed.
+type StatusTransition struct {
+	OperationID string          `json:"operation_id"`
+	From        OperationStatus `json:"from"`
+	To          OperationStatus `json:"to"`
+	// PreviousAmount is set when the amount changed too.
+	PreviousAmount *Money `json:"previous_amount,omitempty"`
+	// Reversal reports whether the operation was reversed, cancelled or declined.
+	Reversal   bool      `json:"reversal"`
+	DetectedAt Timestamp `json:"detected_at"`
+}
+
+// Ledger is the local copy of the operations history, keyed by operation ID. How it is stored is up to
+// the LedgerRepository.
+type Ledger struct {
+	LastSyncAt Timestamp
+	// Complete reports whether a sync reached the oldest operation, so later syncs only need the new pages.
+	Complete    bool
+	Operations  map[string]Operation
+	Transitions []StatusTransition
+}
+
+// NewLedger creates an empty ledger.
+func NewLedger() *Ledger {
+	return &Ledger{
+		Operations:  make(map[string]Operation),
+		Transitions: []StatusTransition{},
+	}
+}
+
+// Upsert records the operation as seen at the given time. It reports whether the operation is new or
+// changed, and returns the transition when its status or amount changed.
+func (l *Ledger) Upsert(operation Operation, now Timestamp) (bool, *StatusTransition) {
+	// The description and the refund links are derived, and computed again when read.
+	operation.Description = ""
+	operation.Refunds, operation.RefundedAmount, operation.RefundOf = nil, nil, ""
+	existing, ok := l.Operations[operation.ID]
+	l.Operations[operation.ID] = operation
+	if !ok {
+		return true, nil
+	}
+	if existing.Status == operation.Status && existing.Amount == operation.Amount {
+		return existing.MerchantName != operation.MerchantName || existing.DescriptionTag != operation.DescriptionTag, nil
+	}
+
+	transition := StatusTransition{
+		OperationID: operation.ID,
+		From:        existing.Status,
+		To:          operation.Status,
+		Reversal:    operation.Status.IsReversal() && !existing.Status.IsReversal(),
+		DetectedAt:  now,
+	}
+	if existing.Amount != operation.Amount {
+		transition.PreviousAmount = &existing.Amount
+	}
+	l.Transitions = append(l.Transitions, transition)
+	return true, &transition
+}
+
+// Sorted returns the operations most recent first, as the API does.
+func (l *Ledger) Sorted() []Operation {
+	operations := slices.Collect(maps.Values(l.Operations))
+	slices.SortFunc(operations, func(a, b Operation) int {
+		if c := b.ExecutedAt.Compare(a.ExecutedAt.Time); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.ID, a.ID)
+	})
+	return operations
+}
+
+// OldestPending returns the execution time of the oldest pending operation executed since the given time,
+// or a zero Timestamp if there is none.
+func (l *Ledger) OldestPending(since time.Time) Timestamp {
+	var oldest Timestamp
+	for _, operation := range l.Operations {
+		if operation.Status != OperationStatusPending || operation.ExecutedAt.Before(since) {
+			continue
+		}
+		if oldest.IsZero() || operation.ExecutedAt.Before(oldest.Time) {
+			oldest = operation.ExecutedAt
+		}
+	}
+	return oldest
+}
+
+// IsReversal reports whether the status means the operation was undone.
+func (s OperationStatus) IsReversal() bool {
+	return s == OperationStatusReversed || s == OperationStatusCancelled || s == OperationStatusDeclined
+}
+
+// LedgerSyncResult summarizes a sync of the ledger with the API.
+type LedgerSyncResult struct {
+	// Full reports whether the whole history was walked, rather than only the pages since the last sync.
+	Full bool `json:"full"`
+	// Truncated reports whether the sync stopped at the page limit before reaching the known operations.
+	Truncated   bool               `json:"truncated"`
+	Fetched     int                `json:"fetched"`
+	New         int                `json:"new"`
+	Updated     int                `json:"updated"`
+	Transitions []StatusTransition `json:"transitions"`
+	Total       int                `json:"total"`
+	SyncedAt    Timestamp          `json:"synced_at"`
+}
+
+// LedgerRepository persists the operations ledger.
+type LedgerRepository interface {
+	// Load returns the stored ledger, or an empty one if there is none yet.
+	Load() (*Ledger, error)
+	Save(ledger *Ledger) error
+}
@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// MerchantStatsSort is the order merchant statistics are sorted in, the largest first.
+type MerchantStatsSort string
+
+// Merchant statistics orders.
+const (
+	MerchantStatsByTotal   MerchantStatsSort = "total"
+	MerchantStatsByVisits  MerchantStatsSort = "visits"
+	MerchantStatsByAverage MerchantStatsSort = "average_ticket"
+)
+
+// MerchantStatsSorts lists the merchant statistics orders, the default first.
+var MerchantStatsSorts = []MerchantStatsSort{MerchantStatsByTotal, MerchantStatsByVisits, MerchantStatsByAverage}
+
+// ParseMerchantStatsSort returns the order with the given name, or MerchantStatsByTotal if it is empty.
+func ParseMerchantStatsSort(name string) (MerchantStatsSort, error) {
+	if name == "" {
+		return MerchantStatsByTotal, nil
+	}
+	if !slices.Contains(MerchantStatsSorts, MerchantStatsSort(name)) {
+		return "", fmt.Errorf("unknown order %q, expected one of %v", name, MerchantStatsSorts)
+	}
+	return MerchantStatsSort(name), nil
+}
+
+// MerchantKey groups the variants of a merchant name: it folds the name with FoldText and drops the
+// trailing store codes, the words with digits in them and stray punctuation, so "PINGO DOCE 0452",
+// "Pingo Doce #12" and "pingo doce" are the same merchant. A name that is only a code is kept as is.
+func MerchantKey(name string) string {
+	words := strings.Fields(FoldText(name))
+	for len(words) > 1 {
+		last := words[len(words)-1]
+		if !strings.ContainsFunc(last, unicode.IsDigit) && strings.ContainsFunc(last, unicode.IsLetter) {
+			break
+		}
+		words = words[:len(words)-1]
+	}
+	return strings.Join(words, " ")
+}
+
+// MerchantStats is the spending at a merchant over a date range.
+type MerchantStats struct {
+	// Merchant is the most frequent spelling of the merchant name.
+	Merchant string `json:"merchant"`
+	// Variants are the spellings of the name grouped under the merchant.
+	Variants []string `json:"variants"`
+	// Total is the amount spent, net of the refunds linked to the payments.
+	Total Money `json:"total"`
+	// Visits is the number of payments.
+	Visits int `json:"visits"`
+	// AverageTicket and MedianTicket are the mean and the median of the payments, net of their refunds.
+	AverageTicket Money     `json:"average_ticket"`
+	MedianTicket  Money     `json:"median_ticket"`
+	FirstVisit    Timestamp `json:"first_visit"`
+	LastVisit     Timestamp `json:"last_visit"`
+	// Share is the percentage of the spending at all the merchants spent at this one.
+	Share float64 `json:"share"`
+}
+
+// MerchantsSummary is the spending by merchant over a date range.
+type MerchantsSummary struct {
+	Range DateRange `json:"range"`
+	// Total is the amount spent at all the merchants, net of the refunds linked to the payments.
+	Total Money `json:"total"`
+	// Merchants is sorted by the chosen order, the largest first.
+	Merchants []MerchantStats `json:"merchants"`
+}
+
+// SummarizeMerchants groups the payments executed within the range by MerchantKey. It counts the payments
+// as SummarizeSpending does, net of the refunds linked to them with LinkRefunds, so a merchant total is the
+// sum of its tickets. The operations without a merchant and the refunds no payment was found for are left out.
+func SummarizeMerchants(operations []Operation, r DateRange, order MerchantStatsSort) (*MerchantsSummary, error) {
+	type merchant struct {
+		stats    MerchantStats
+		tickets  []int64
+		variants map[string]int
+	}
+	summary := &MerchantsSummary{Range: r, Total: NewMoney(0, DefaultCurrency), Merchants: []MerchantStats{}}
+	merchants := make(map[string]*merchant)
+	var keys []string
+
+	for _, operation := range operations {
+		spent, _, payment, ok := spendingOf(operation)
+		if !ok || !payment || operation.MerchantName == "" || !r.Contains(operation.ExecutedAt.CalendarDate()) {
+			continue
+		}
+		key := MerchantKey(operation.MerchantName)
+		m, found := merchants[key]
+		if !found {
+			m = &merchant{stats: MerchantStats{Total: NewMoney(0, DefaultCurrency)}, variants: make(map[string]int)}
+			merchants[key] = m
+			keys = append(keys, key)
+		}
+
+		total, err := m.stats.Total.Add(spent)
+		if err != nil {
+			return nil, fmt.Errorf("operation %s: %w", operation.ID, err)
+		}
+		m.stats.Total = total
+		m.stats.Visits++
+		m.tickets = append(m.tickets, spent.MinorUnits)
+		m.variants[operation.MerchantName]++
+		if m.stats.FirstVisit.IsZero() || operation.ExecutedAt.Before(m.stats.FirstVisit.Time) {
+			m.stats.FirstVisit = operation.ExecutedAt
+		}
+		if m.stats.LastVisit.IsZero() || operation.ExecutedAt.After(m.stats.LastVisit.Time) {
+			m.stats.LastVisit = operation.ExecutedAt
+		}
+	}
+
+	for _, key := range keys {
+		m := merchants[key]
+		total, err := summary.Total.Add(m.stats.Total)
+		if err != nil {
+			return nil, fmt.Errorf("merchant %s: %w", key, err)
+		}
+		summary.Total = total
+		m.stats.Variants = slices.Collect(maps.Keys(m.variants))
+		slices.SortFunc(m.stats.Variants, func(a, b string) int {
+			return cmp.Or(cmp.Compare(m.variants[b], m.variants[a]), cmp.Compare(a, b))
+		})
+		m.stats.Merchant = m.stats.Variants[0]
+		currency := m.stats.Total.Currency
+		m.stats.AverageTicket = NewMoney(roundDiv(m.stats.Total.MinorUnits, int64(m.stats.Visits)), currency)
+		m.stats.MedianTicket = NewMoney(median(m.tickets), currency)
+		summary.Merchants = append(summary.Merchants, m.stats)
+	}
+
+	for i := range summary.Merchants {
+		if summary.Total.MinorUnits > 0 {
+			share := float64(summary.Merchants[i].Total.MinorUnits) / float64(summary.Total.MinorUnits) * 100
+			summary.Merchants[i].Share = math.Round(share*100) / 100
+		}
+	}
+	slices.SortStableFunc(summary.Merchants, func(a, b MerchantStats) int {
+		switch order {
+		case MerchantStatsByVisits:
+			return cmp.Or(cmp.Compare(b.Visits, a.Visits), cmp.Compare(b.Total.MinorUnits, a.Total.MinorUnits))
+		case MerchantStatsByAverage:
+			return cmp.Compare(b.AverageTicket.MinorUnits, a.AverageTicket.MinorUnits)
+		default:
+			return cmp.Or(cmp.Compare(b.Total.MinorUnits, a.Total.MinorUnits), cmp.Compare(b.Visits, a.Visits))
+		}
+	})
+	return summary, nil
+}
+
+// roundDiv divides rounding half away from zero.
+func roundDiv(dividend, divisor int64) int64 {
+	return int64(math.Round(float64(dividend) / float64(divisor)))
+}
+
+// median returns the middle value, or the rounded mean of the two middle values of an even count.
+func median(values []int64) int64 {
+	sorted := slices.Sorted(slices.Values(values))
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[middle]
+	}
+	return roundDiv(sorted[middle-1]+sorted[middle], 2)
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMerchantKey(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"PINGO DOCE 0452", "pingo doce"},
+		{"Pingo Doce #12", "pingo doce"},
+		{"  pingo   doce ", "pingo doce"},
+		{"Farmácia São João", "farmacia sao joao"},
+		{"CONTINENTE LX12 -", "continente"},
+		{"Padaria 2 Irmãos", "padaria 2 irmaos"},
+		{"12345", "12345"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MerchantKey(tt.name); got != tt.want {
+				t.Errorf("MerchantKey(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		values []int64
+		want   int64
+	}{
+		{[]int64{700}, 700},
+		{[]int64{900, 100, 500}, 500},
+		{[]int64{100, 400}, 250},
+		{[]int64{100, 200, 400, 101}, 151},
+	}
+	for _, tt := range tests {
+		if got := median(tt.values); got != tt.want {
+			t.Errorf("median(%v) = %d, want %d", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestSummarizeMerchants(t *testing.T) {
+	at := func(day int) Timestamp {
+		return NewTimestamp(time.Date(2025, time.March, day, 12, 0, 0, 0, time.UTC))
+	}
+	paid := func(id string, day int, merchant string, cents int64) Operation {
+		return Operation{ID: id, Amount: NewMoney(-cents, DefaultCurrency), IsDebit: true, ExecutedAt: at(day),
+			MerchantName: merchant, Type: OperationTypeCardTransaction}
+	}
+	refunded := func(id string, day int, merchant string, cents int64) Operation {
+		return Operation{ID: id, Amount: NewMoney(cents, DefaultCurrency), ExecutedAt: at(day),
+			MerchantName: merchant, Type: OperationTypeRefund}
+	}
+	operations := LinkRefunds([]Operation{
+		paid("p1", 2, "PINGO DOCE 0452", 1000),
+		paid("p2", 5, "Pingo Doce", 3000),
+		paid("p3", 9, "Pingo Doce", 500),
+		// Refunds 10,00 € of the 30,00 € payment.
+		refunded("r1", 10, "Pingo Doce", 1000),
+		// A refund at a merchant without payments in the range, and one larger than any payment left.
+		refunded("r2", 11, "Worten", 5000),
+		refunded("r3", 12, "Pingo Doce", 9000),
+		paid("p4", 7, "Farmácia", 2000),
+		paid("nameless", 8, "", 4000),
+	})
+
+	summary, err := SummarizeMerchants(operations, DateRange{From: NewDate(2025, time.March, 1), To: NewDate(2025, time.March, 31)}, MerchantStatsByTotal)
+	if err != nil {
+		t.Fatalf("SummarizeMerchants() error = %v", err)
+	}
+	if summary.Total != NewMoney(5500, DefaultCurrency) || len(summary.Merchants) != 2 {
+		t.Fatalf("summary = %s over %d merchants, want 5500 cents over 2", summary.Total, len(summary.Merchants))
+	}
+
+	pingo := summary.Merchants[0]
+	if pingo.Merchant != "Pingo Doce" || len(pingo.Variants) != 2 || pingo.Visits != 3 {
+		t.Errorf("merchant = %q with %v over %d visits, want the most frequent Pingo Doce with 2 variants over 3 visits", pingo.Merchant, pingo.Variants, pingo.Visits)
+	}
+	// The tickets are 10,00 €, 20,00 € net of the refund and 5,00 €: the unlinked refund counts in neither
+	// the total nor the tickets, so the average and the median describe the same payments.
+	if pingo.Total != NewMoney(3500, DefaultCurrency) || pingo.AverageTicket != NewMoney(1167, DefaultCurrency) || pingo.MedianTicket != NewMoney(1000, DefaultCurrency) {
+		t.Errorf("total, average, median = %s, %s, %s, want 35,00 €, 11,67 € and 10,00 €", pingo.Total, pingo.AverageTicket, pingo.MedianTicket)
+	}
+	if !pingo.FirstVisit.Equal(at(2).Time) || !pingo.LastVisit.Equal(at(9).Time) {
+		t.Errorf("visits from %s to %s, want from March 2nd to 9th", pingo.FirstVisit, pingo.LastVisit)
+	}
+	if pingo.Share != 63.64 || summary.Merchants[1].Share != 36.36 {
+		t.Errorf("shares = %v and %v, want 63.64 and 36.36", pingo.Share, summary.Merchants[1].Share)
+	}
+}
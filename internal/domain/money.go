@@ -0,0 +1,250 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+)
+
+// DefaultCurrency is the currency Coverflex operates in.
+const DefaultCurrency = "EUR"
+
+// ErrCurrencyMismatch is returned when combining amounts of different currencies.
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// currencyExponents is the number of minor unit digits of the currencies that do not use cents.
+var currencyExponents = map[string]int{
+	"JPY": 0,
+	"KRW": 0,
+	"CLP": 0,
+	"BHD": 3,
+	"KWD": 3,
+}
+
+// currencySymbols maps the ISO 4217 codes to their display symbol.
+var currencySymbols = map[string]string{
+	"EUR": "€",
+	"USD": "$",
+	"GBP": "£",
+	"BRL": "R$",
+	"JPY": "¥",
+	"CHF": "CHF",
+}
+
+// Money is an amount of money expressed in the minor units of its currency (e.g. cents for EUR),
+// so arithmetic is always exact. The zero value is zero in no currency, and can be combined with any currency.
+type Money struct {
+	// MinorUnits is the amount in the smallest unit of the currency: 1234 EUR minor units are 12,34 €.
+	MinorUnits int64
+	// Currency is the ISO 4217 currency code.
+	Currency string
+}
+
+// NewMoney returns the amount of minor units of the given currency.
+func NewMoney(minorUnits int64, currency string) Money {
+	return Money{MinorUnits: minorUnits, Currency: strings.ToUpper(currency)}
+}
+
+// MoneyFromMajor returns the amount given in major units, e.g. 12.34 EUR, rounded to the nearest minor unit.
+func MoneyFromMajor(amount float64, currency string) Money {
+	money := NewMoney(0, currency)
+	money.MinorUnits = int64(math.Round(amount * math.Pow10(money.Exponent())))
+	return money
+}
+
+// Exponent returns the number of decimal digits of the currency minor unit.
+func (m Money) Exponent() int {
+	if exponent, ok := currencyExponents[m.Currency]; ok {
+		return exponent
+	}
+	return 2
+}
+
+// IsZero reports whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.MinorUnits == 0
+}
+
+// IsNegative reports whether the amount is below zero.
+func (m Money) IsNegative() bool {
+	return m.MinorUnits < 0
+}
+
+// Neg returns the amount with the opposite sign.
+func (m Money) Neg() Money {
+	return Money{MinorUnits: -m.MinorUnits, Currency: m.Currency}
+}
+
+// Abs returns the absolute amount.
+func (m Money) Abs() Money {
+	if m.MinorUnits < 0 {
+		return m.Neg()
+	}
+	return m
+}
+
+// Add returns the sum of both amounts. It fails with ErrCurrencyMismatch if their currencies differ.
+func (m Money) Add(other Money) (Money, error) {
+	currency, err := commonCurrency(m, other)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{MinorUnits: m.MinorUnits + other.MinorUnits, Currency: currency}, nil
+}
+
+// Sub returns the difference of both amounts. It fails with ErrCurrencyMismatch if their currencies differ.
+func (m Money) Sub(other Money) (Money, error) {
+	return m.Add(other.Neg())
+}
+
+// Cmp compares both amounts, returning -1, 0 or +1. It fails with ErrCurrencyMismatch if their currencies differ.
+func (m Money) Cmp(other Money) (int, error) {
+	if _, err := commonCurrency(m, other); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.MinorUnits < other.MinorUnits:
+		return -1, nil
+	case m.MinorUnits > other.MinorUnits:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// SumMoney adds up all the amounts. It fails with ErrCurrencyMismatch if their currencies differ.
+func SumMoney(amounts ...Money) (Money, error) {
+	var total Money
+	for _, amount := range amounts {
+		var err error
+		if total, err = total.Add(amount); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// commonCurrency returns the currency shared by both amounts. A zero amount without currency matches any currency.
+func commonCurrency(a, b Money) (string, error) {
+	switch {
+	case a.Currency == b.Currency:
+		return a.Currency, nil
+	case a.Currency == "" && a.IsZero():
+		return b.Currency, nil
+	case b.Currency == "" && b.IsZero():
+		return a.Currency, nil
+	default:
+		return "", fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, a.Currency, b.Currency)
+	}
+}
+
+// Decimal returns the amount as a plain decimal number in major units, e.g. "12.34" or "-0.50".
+func (m Money) Decimal() string {
+	return m.decimal(".", "")
+}
+
+// Format returns the amount formatted for display in the given locale:
+// "1 234,56 €" for Portuguese, "1.234,56 €" for Spanish and "€1,234.56" for English.
+// Portuguese is used for unknown locales.
+func (m Money) Format(locale string) string {
+	symbol, ok := currencySymbols[m.Currency]
+	if !ok {
+		symbol = m.Currency
+	}
+
+	language, _, _ := strings.Cut(strings.ToLower(locale), "-")
+	switch language {
+	case "en":
+		amount := m.Abs().decimal(".", ",")
+		if m.IsNegative() {
+			return "-" + symbol + amount
+		}
+		return symbol + amount
+	case "es":
+		return strings.TrimSpace(m.decimal(",", ".") + " " + symbol)
+	default:
+		return strings.TrimSpace(m.decimal(",", " ") + " " + symbol)
+	}
+}
+
+// String returns the amount formatted with the default locale, e.g. "12,34 €".
+func (m Money) String() string {
+	return m.Format("")
+}
+
+// decimal renders the amount in major units with the given decimal and thousands separators.
+func (m Money) decimal(decimalSep, thousandsSep string) string {
+	digits := strconv.FormatInt(m.Abs().MinorUnits, 10)
+	exponent := m.Exponent()
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	integer, fraction := digits[:len(digits)-exponent], digits[len(digits)-exponent:]
+
+	if thousandsSep != "" && len(integer) > 3 {
+		var grouped strings.Builder
+		for i, digit := range integer {
+			if i > 0 && (len(integer)-i)%3 == 0 {
+				grouped.WriteString(thousandsSep)
+			}
+			grouped.WriteRune(digit)
+		}
+		integer = grouped.String()
+	}
+
+	result := integer
+	if exponent > 0 {
+		result += decimalSep + fraction
+	}
+	if m.IsNegative() {
+		result = "-" + result
+	}
+	return result
+}
+
+// moneyJSON is the JSON representation of Money.
+type moneyJSON struct {
+	MinorUnits int64  `json:"minor_units"`
+	Currency   string `json:"currency"`
+	Decimal    string `json:"decimal,omitempty"`
+	Formatted  string `json:"formatted,omitempty"`
+}
+
+// MarshalJSON encodes the amount with both its raw minor units and its decimal and formatted representations.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{
+		MinorUnits: m.MinorUnits,
+		Currency:   m.Currency,
+		Decimal:    m.Decimal(),
+		Formatted:  m.String(),
+	})
+}
+
+// UnmarshalJSON decodes the representation produced by MarshalJSON, ignoring its derived fields.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var raw moneyJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*m = NewMoney(raw.MinorUnits, raw.Currency)
+	return nil
+}
+
+// JSONSchema describes the JSON produced by MarshalJSON.
+func (Money) JSONSchema() *jsonschema.Schema {
+	properties := jsonschema.NewProperties()
+	properties.Set("minor_units", &jsonschema.Schema{Type: "integer", Description: "Amount in the minor units of the currency (e.g. cents): 1234 EUR minor units are 12,34 €."})
+	properties.Set("currency", &jsonschema.Schema{Type: "string", Description: "ISO 4217 currency code."})
+	properties.Set("decimal", &jsonschema.Schema{Type: "string", Description: "Amount in major units as a decimal number, e.g. \"12.34\"."})
+	properties.Set("formatted", &jsonschema.Schema{Type: "string", Description: "Amount formatted for display, e.g. \"12,34 €\"."})
+	return &jsonschema.Schema{
+		Type:       "object",
+		Properties: properties,
+		Required:   []string{"minor_units", "currency", "decimal", "formatted"},
+	}
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestMoneyArithmetic(t *testing.T) {
+	euros := NewMoney(1250, "eur")
+	if euros.Currency != "EUR" {
+		t.Errorf("currency = %q, want it upper-cased", euros.Currency)
+	}
+
+	sum, err := SumMoney(euros, NewMoney(-300, DefaultCurrency), Money{})
+	if err != nil || sum != NewMoney(950, DefaultCurrency) {
+		t.Errorf("SumMoney() = %s, %v, want 9,50 €", sum, err)
+	}
+	var zero Money
+	if diff, err := zero.Sub(euros); err != nil || diff != NewMoney(-1250, DefaultCurrency) {
+		t.Errorf("zero Sub() = %s, %v, want -12,50 € as the zero value takes any currency", diff, err)
+	}
+	if c, err := euros.Cmp(euros.Neg()); err != nil || c != 1 {
+		t.Errorf("Cmp() = %d, %v, want 1", c, err)
+	}
+	if _, err := euros.Add(NewMoney(100, "USD")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Add() of another currency error = %v, want ErrCurrencyMismatch", err)
+	}
+	if _, err := euros.Cmp(NewMoney(0, "USD")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Cmp() with zero dollars error = %v, want ErrCurrencyMismatch as only the zero value takes any currency", err)
+	}
+
+	tests := []struct {
+		amount   float64
+		currency string
+		want     int64
+	}{
+		{12.34, "EUR", 1234},
+		{0.1 + 0.2, "EUR", 30},
+		{-7.005, "EUR", -701},
+		{1500, "JPY", 1500},
+		{1.2345, "KWD", 1235},
+	}
+	for _, tt := range tests {
+		if got := MoneyFromMajor(tt.amount, tt.currency); got.MinorUnits != tt.want {
+			t.Errorf("MoneyFromMajor(%v, %s) = %d minor units, want %d", tt.amount, tt.currency, got.MinorUnits, tt.want)
+		}
+	}
+}
+
+func TestMoneyFormat(t *testing.T) {
+	tests := []struct {
+		money  Money
+		locale string
+		want   string
+	}{
+		{NewMoney(123456, "EUR"), "pt", "1 234,56 €"},
+		{NewMoney(123456, "EUR"), "es-ES", "1.234,56 €"},
+		{NewMoney(123456, "EUR"), "en", "€1,234.56"},
+		{NewMoney(-50, "EUR"), "en", "-€0.50"},
+		{NewMoney(-50, "EUR"), "pt", "-0,50 €"},
+		{NewMoney(123456789, "EUR"), "", "1 234 567,89 €"},
+		{NewMoney(1500, "JPY"), "pt", "1 500 ¥"},
+		{NewMoney(1234, "KWD"), "en", "KWD1.234"},
+		{NewMoney(5, "EUR"), "fr", "0,05 €"},
+	}
+	for _, tt := range tests {
+		if got := tt.money.Format(tt.locale); got != tt.want {
+			t.Errorf("%d %s Format(%q) = %q, want %q", tt.money.MinorUnits, tt.money.Currency, tt.locale, got, tt.want)
+		}
+	}
+	if got := NewMoney(-123456, "EUR").Decimal(); got != "-1234.56" {
+		t.Errorf("Decimal() = %q, want -1234.56", got)
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	data, err := json.Marshal(NewMoney(-1234, DefaultCurrency))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := `{"minor_units":-1234,"currency":"EUR","decimal":"-12.34","formatted":"-12,34 €"}`; string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+	var decoded Money
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded != NewMoney(-1234, DefaultCurrency) {
+		t.Errorf("Unmarshal() = %s, %v, want the amount back", decoded, err)
+	}
+}
@@ -0,0 +1,200 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// ErrOperationNotFound is returned when no operation has the requested ID.
+var ErrOperationNotFound = errors.New("operation not found")
+
+// DescriptionParam is a key/value pair used to render the description of an operation.
+type DescriptionParam struct {
+	Key   string `json:"key"`
+	Value any    `json:"value"`
+}
+
+// Operation is a single movement of money in the employee account.
+type Operation struct {
+	ID     string `json:"id"`
+	Amount Money  `json:"amount"`
+	// Description is the human-readable sentence rendered from DescriptionTag and DescriptionParams.
+	Description       string             `json:"description,omitempty"`
+	DescriptionTag    string             `json:"description_tag"`
+	DescriptionParams []DescriptionParam `json:"description_params"`
+	CategorySlug      CategorySlug       `json:"category_slug"`
+	ProductSlug       string             `json:"product_slug"`
+	ExecutedAt        Timestamp          `json:"executed_at"`
+	IsDebit           bool               `json:"is_debit"`
+	MerchantName      string             `json:"merchant_name,omitempty"`
+	Status            OperationStatus    `json:"status"`
+	Type              OperationType      `json:"type"`
+	// Refunds are the IDs of the credits linked as refunds of this payment by LinkRefunds, and RefundedAmount their total.
+	Refunds        []string `json:"refunds,omitempty"`
+	RefundedAmount *Money   `json:"refunded_amount,omitempty"`
+	// RefundOf is the ID of the payment this credit was linked to as its refund.
+	RefundOf string `json:"refund_of,omitempty"`
+}
+
+// Params returns the description params keyed by their key.
+func (o Operation) Params() map[string]any {
+	params := make(map[string]any, len(o.DescriptionParams))
+	for _, param := range o.DescriptionParams {
+		params[param.Key] = param.Value
+	}
+	return params
+}
+
+// GetOperationsParams holds the parameters for the GetOperations method.
+type GetOperationsParams struct {
+	Page    int
+	PerPage int
+	// MaxPages caps the number of pages walked by PaginateOperations. Zero means no limit.
+	MaxPages int
+	Filters  OperationsFilters
+}
+
+// Default pagination of GetOperations.
+const (
+	DefaultOperationsPage    = 1
+	DefaultOperationsPerPage = 20
+)
+
+// GetOperationsOption defines a function that modifies GetOperationsParams.
+type GetOperationsOption func(*GetOperationsParams)
+
+// NewGetOperationsParams returns the default parameters with the options applied.
+func NewGetOperationsParams(opts ...GetOperationsOption) *GetOperationsParams {
+	params := &GetOperationsParams{
+		Page:    DefaultOperationsPage,
+		PerPage: DefaultOperationsPerPage,
+	}
+	for _, opt := range opts {
+		opt(params)
+	}
+	return params
+}
+
+// WithOperationsPage sets the page number for the operations request.
+func WithOperationsPage(page int) GetOperationsOption {
+	return func(params *GetOperationsParams) {
+		if page > 0 {
+			params.Page = page
+		}
+	}
+}
+
+// WithOperationsPerPage sets the number of operations to return per page.
+func WithOperationsPerPage(perPage int) GetOperationsOption {
+	return func(params *GetOperationsParams) {
+		if perPage > 0 {
+			params.PerPage = perPage
+		}
+	}
+}
+
+// WithOperationsPeriod keeps the operations executed within the given days.
+func WithOperationsPeriod(period DateRange) GetOperationsOption {
+	return func(params *GetOperationsParams) {
+		params.Filters.Period = period
+	}
+}
+
+// WithOperationsMaxPages caps the number of pages walked when iterating over all the operations.
+func WithOperationsMaxPages(maxPages int) GetOperationsOption {
+	return func(params *GetOperationsParams) {
+		if maxPages > 0 {
+			params.MaxPages = maxPages
+		}
+	}
+}
+
+// WithOperationsFilterType sets the type filter for the operations request.
+func WithOperationsFilterType(filterType OperationType) GetOperationsOption {
+	return func(params *GetOperationsParams) {
+		params.Filters.Types = ValueFilter[OperationType]{Include: []OperationType{filterType}}
+	}
+}
+
+// WithOperationsFilters sets every filter for the operations request at once, keeping the period
+// if the given filters have none.
+func WithOperationsFilters(filters OperationsFilters) GetOperationsOption {
+	return func(params *GetOperationsParams) {
+		if filters.Period.IsZero() {
+			filters.Period = params.Filters.Period
+		}
+		params.Filters = filters
+	}
+}
+
+// ErrPageLimitReached is yielded by PaginateOperations when it stops at MaxPages while more pages may remain.
+var ErrPageLimitReached = errors.New("page limit reached")
+
+// OperationsPageFetcher fetches a single page of operations as returned by the API.
+type OperationsPageFetcher func(ctx context.Context, opts ...GetOperationsOption) ([]Operation, error)
+
+// PaginateOperations walks the pages of operations from the configured page until a page comes back
+// short, MaxPages pages were fetched or the operations are older than the period filter.
+// Client-side filters are applied to every page. It stops early when the caller breaks out of the loop,
+// and yields the error and stops when a page cannot be fetched, or ErrPageLimitReached when it stops
+// at MaxPages after a full page.
+func PaginateOperations(ctx context.Context, fetch OperationsPageFetcher, opts ...GetOperationsOption) iter.Seq2[Operation, error] {
+	return func(yield func(Operation, error) bool) {
+		params := NewGetOperationsParams(opts...)
+		for fetched := 0; ; fetched++ {
+			if params.MaxPages > 0 && fetched == params.MaxPages {
+				yield(Operation{}, ErrPageLimitReached)
+				return
+			}
+			page := params.Page + fetched
+			operations, err := fetch(ctx, append(slices.Clip(opts), WithOperationsPage(page))...)
+			if err != nil {
+				yield(Operation{}, fmt.Errorf("error fetching operations page %d: %w", page, err))
+				return
+			}
+			for _, operation := range operations {
+				if params.Filters.IsPast(operation) {
+					return
+				}
+				if !params.Filters.Matches(operation) {
+					continue
+				}
+				if !yield(operation, nil) {
+					return
+				}
+			}
+			if len(operations) < params.PerPage {
+				return
+			}
+		}
+	}
+}
+
+// FilteredOperationsPage returns the configured page of the operations that pass the client-side filters,
+// walking the API pages from the first one. When MaxPages pages are walked before the page is complete, it
+// returns the operations found so far along with ErrPageLimitReached.
+func FilteredOperationsPage(ctx context.Context, fetch OperationsPageFetcher, opts ...GetOperationsOption) ([]Operation, error) {
+	params := NewGetOperationsParams(opts...)
+	skip := (params.Page - 1) * params.PerPage
+	operations := make([]Operation, 0, params.PerPage)
+	for operation, err := range PaginateOperations(ctx, fetch, append(slices.Clip(opts), WithOperationsPage(1))...) {
+		if errors.Is(err, ErrPageLimitReached) {
+			return operations, err
+		}
+		if err != nil {
+			return nil, err
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+		operations = append(operations, operation)
+		if len(operations) == params.PerPage {
+			break
+		}
+	}
+	return operations, nil
+}
@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// cursorMaxBacktrack caps how many pages are stepped back when the operations shifted towards the first page.
+const cursorMaxBacktrack = 5
+
+// ErrInvalidCursor is returned when a cursor cannot be decoded.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// OperationsCursor is a stable position in the operations list, most recent first: right after the last
+// operation returned. New operations shift the API pages, so the page is only a hint of where to resume.
+type OperationsCursor struct {
+	// ExecutedAt is the execution time of the last operation returned.
+	ExecutedAt time.Time `json:"t"`
+	// IDs are the operations returned that were executed at ExecutedAt, as several may share it.
+	IDs []string `json:"ids"`
+	// Page is the API page of PerPage operations the last operation was on.
+	Page    int `json:"p"`
+	PerPage int `json:"n"`
+}
+
+// OperationsCursorPage is a page of operations and the cursor to the next one, nil when there are no more.
+type OperationsCursorPage struct {
+	Operations []Operation
+	Next       *OperationsCursor
+	// Partial reports that MaxPages pages were walked before PerPage operations passed the filters. Next
+	// resumes the walk.
+	Partial bool
+}
+
+// NewOperationsCursor returns the cursor right after the last of the operations, found on the given API page.
+// The previous cursor, if any, keeps the operations returned before that share its execution time.
+func NewOperationsCursor(operations []Operation, previous *OperationsCursor, page, perPage int) *OperationsCursor {
+	if len(operations) == 0 {
+		return previous
+	}
+	last := operations[len(operations)-1].ExecutedAt.Time
+	cursor := &OperationsCursor{ExecutedAt: last, Page: page, PerPage: perPage}
+	if previous != nil && previous.ExecutedAt.Equal(last) {
+		cursor.IDs = slices.Clone(previous.IDs)
+	}
+	for _, operation := range operations {
+		if operation.ExecutedAt.Equal(last) {
+			cursor.IDs = append(cursor.IDs, operation.ID)
+		}
+	}
+	return cursor
+}
+
+// ParseOperationsCursor decodes a cursor produced by Encode.
+func ParseOperationsCursor(value string) (*OperationsCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	var cursor OperationsCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	if cursor.ExecutedAt.IsZero() || cursor.Page < 1 || cursor.PerPage < 1 {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// Encode returns the cursor as an opaque string.
+func (c *OperationsCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Precedes reports whether the operation comes after the cursor, so it was not returned yet.
+// Operations newer than the cursor, including the ones that arrived since, are behind it.
+func (c *OperationsCursor) Precedes(operation Operation) bool {
+	switch operation.ExecutedAt.Compare(c.ExecutedAt) {
+	case -1:
+		return true
+	case 1:
+		return false
+	default:
+		return !slices.Contains(c.IDs, operation.ID)
+	}
+}
+
+// OperationsPageAfter returns the PerPage operations that pass the filters right after the cursor, or from
+// the first one if it is nil. It resumes at the page hinted by the cursor, stepping back while that page
+// starts past the cursor, and skips the operations already returned, so shifted pages cause no duplicates.
+func OperationsPageAfter(ctx context.Context, fetch OperationsPageFetcher, cursor *OperationsCursor, opts ...GetOperationsOption) (*OperationsCursorPage, error) {
+	params := NewGetOperationsParams(opts...)
+	fetched := make(map[int][]Operation)
+	fetchPage := func(page int) ([]Operation, error) {
+		if operations, ok := fetched[page]; ok {
+			return operations, nil
+		}
+		operations, err := fetch(ctx, append(slices.Clip(opts), WithOperationsPage(page))...)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching operations page %d: %w", page, err)
+		}
+		fetched[page] = operations
+		return operations, nil
+	}
+
+	start := 1
+	if cursor != nil {
+		start = max((cursor.Page-1)*cursor.PerPage/params.PerPage+1, 1)
+		for backtracked := 0; start > 1 && backtracked < cursorMaxBacktrack; backtracked++ {
+			operations, err := fetchPage(start)
+			if err != nil {
+				return nil, err
+			}
+			if len(operations) > 0 && !cursor.Precedes(operations[0]) {
+				break
+			}
+			start--
+		}
+	}
+
+	result := &OperationsCursorPage{Operations: make([]Operation, 0, params.PerPage)}
+	// examined are the operations after the cursor walked so far, whether they pass the filters or not.
+	var examined []Operation
+	page := start
+	for ; params.MaxPages == 0 || page-start < params.MaxPages; page++ {
+		operations, err := fetchPage(page)
+		if err != nil {
+			return nil, err
+		}
+		for _, operation := range operations {
+			if cursor != nil && !cursor.Precedes(operation) {
+				continue
+			}
+			examined = append(examined, operation)
+			if params.Filters.IsPast(operation) {
+				return result, nil
+			}
+			if !params.Filters.Matches(operation) {
+				continue
+			}
+			result.Operations = append(result.Operations, operation)
+			if len(result.Operations) == params.PerPage {
+				result.Next = NewOperationsCursor(result.Operations, cursor, page, params.PerPage)
+				return result, nil
+			}
+		}
+		if len(operations) < params.PerPage {
+			return result, nil
+		}
+	}
+
+	// The page limit was reached: resume after the last operation walked, even if it did not pass the filters.
+	result.Next = NewOperationsCursor(examined, cursor, page-1, params.PerPage)
+	result.Partial = true
+	return result, nil
+}
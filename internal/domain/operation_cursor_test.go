@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+// feed is an operations list, most recent first, served in pages like the API does.
+type feed struct {
+	operations []Operation
+	fetches    int
+}
+
+func (f *feed) fetch(_ context.Context, opts ...GetOperationsOption) ([]Operation, error) {
+	f.fetches++
+	params := NewGetOperationsParams(opts...)
+	start := min((params.Page-1)*params.PerPage, len(f.operations))
+	end := min(start+params.PerPage, len(f.operations))
+	return f.operations[start:end], nil
+}
+
+// arrive puts new operations at the top of the feed, shifting every page.
+func (f *feed) arrive(operations ...Operation) {
+	f.operations = append(slices.Clone(operations), f.operations...)
+}
+
+// minutely returns count operations one minute apart, most recent first, with IDs prefixed by prefix.
+func minutely(prefix string, count int, newest time.Time) []Operation {
+	operations := make([]Operation, count)
+	for i := range operations {
+		operations[i] = Operation{
+			ID:           fmt.Sprintf("%s%d", prefix, i),
+			ExecutedAt:   NewTimestamp(newest.Add(-time.Duration(i) * time.Minute)),
+			CategorySlug: CategoryMeal,
+		}
+	}
+	return operations
+}
+
+// walk follows the cursors from the first page until there are no more, returning the IDs in order.
+func walk(t *testing.T, f *feed, between func(), opts ...GetOperationsOption) []string {
+	t.Helper()
+	var ids []string
+	var cursor *OperationsCursor
+	for page := 1; ; page++ {
+		result, err := OperationsPageAfter(t.Context(), f.fetch, cursor, opts...)
+		if err != nil {
+			t.Fatalf("OperationsPageAfter() error = %v", err)
+		}
+		for _, operation := range result.Operations {
+			ids = append(ids, operation.ID)
+		}
+		if result.Next == nil {
+			return ids
+		}
+		if page > 20 {
+			t.Fatalf("OperationsPageAfter() never ends, returned %v", ids)
+		}
+		cursor = result.Next
+		if between != nil {
+			between()
+		}
+	}
+}
+
+func idsOf(operations []Operation) []string {
+	ids := make([]string, len(operations))
+	for i, operation := range operations {
+		ids[i] = operation.ID
+	}
+	return ids
+}
+
+var noon = time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+func TestOperationsPageAfterWalksEveryOperationOnce(t *testing.T) {
+	f := &feed{operations: minutely("op", 7, noon)}
+	got := walk(t, f, nil, WithOperationsPerPage(3))
+	if want := idsOf(f.operations); !slices.Equal(got, want) {
+		t.Errorf("walked %v, want %v", got, want)
+	}
+}
+
+func TestOperationsPageAfterSkipsOperationsThatShiftedThePages(t *testing.T) {
+	f := &feed{operations: minutely("old", 9, noon)}
+	want := idsOf(f.operations)
+	// New operations arrive after every page, pushing the ones not returned yet to later API pages.
+	arrivals := 0
+	got := walk(t, f, func() {
+		arrivals++
+		f.arrive(minutely(fmt.Sprintf("new%d-", arrivals), 4, noon.Add(time.Duration(arrivals)*time.Hour))...)
+	}, WithOperationsPerPage(3))
+	if !slices.Equal(got, want) {
+		t.Errorf("walked %v, want %v", got, want)
+	}
+}
+
+func TestOperationsPageAfterKeepsOperationsSharingTheCursorTime(t *testing.T) {
+	// Four operations share a time across the boundary of the first page.
+	f := &feed{operations: []Operation{
+		{ID: "a", ExecutedAt: NewTimestamp(noon)},
+		{ID: "b", ExecutedAt: NewTimestamp(noon.Add(-time.Minute))},
+		{ID: "c", ExecutedAt: NewTimestamp(noon.Add(-time.Minute))},
+		{ID: "d", ExecutedAt: NewTimestamp(noon.Add(-time.Minute))},
+		{ID: "e", ExecutedAt: NewTimestamp(noon.Add(-time.Minute))},
+		{ID: "f", ExecutedAt: NewTimestamp(noon.Add(-time.Hour))},
+	}}
+	got := walk(t, f, nil, WithOperationsPerPage(2))
+	if want := []string{"a", "b", "c", "d", "e", "f"}; !slices.Equal(got, want) {
+		t.Errorf("walked %v, want %v", got, want)
+	}
+}
+
+func TestOperationsPageAfterStopsAtThePageLimit(t *testing.T) {
+	// Only the last two of ten operations pass the filter, so two pages of three find none of them.
+	operations := minutely("op", 10, noon)
+	operations[8].CategorySlug = CategoryHealth
+	operations[9].CategorySlug = CategoryHealth
+	f := &feed{operations: operations}
+	opts := []GetOperationsOption{
+		WithOperationsPerPage(3),
+		WithOperationsMaxPages(2),
+		WithOperationsFilters(OperationsFilters{Categories: ValueFilter[CategorySlug]{Include: []CategorySlug{CategoryHealth}}}),
+	}
+
+	first, err := OperationsPageAfter(t.Context(), f.fetch, nil, opts...)
+	if err != nil {
+		t.Fatalf("OperationsPageAfter() error = %v", err)
+	}
+	if !first.Partial || len(first.Operations) != 0 || first.Next == nil {
+		t.Fatalf("first page = %d operations, partial %v, next %v, want none, partial and a next cursor",
+			len(first.Operations), first.Partial, first.Next)
+	}
+	if f.fetches != 2 {
+		t.Errorf("fetched %d pages, want 2", f.fetches)
+	}
+	if want := []string{"op5"}; !slices.Equal(first.Next.IDs, want) {
+		t.Errorf("next cursor IDs = %v, want it after the last operation walked %v", first.Next.IDs, want)
+	}
+
+	// Resuming walks on from the cursor, each call still bounded by the page limit, until no pages are left.
+	var found []string
+	next := first.Next
+	for calls := 0; next != nil; calls++ {
+		if calls == 5 {
+			t.Fatalf("resuming never ends, found %v", found)
+		}
+		result, err := OperationsPageAfter(t.Context(), f.fetch, next, opts...)
+		if err != nil {
+			t.Fatalf("OperationsPageAfter() error = %v", err)
+		}
+		found = append(found, idsOf(result.Operations)...)
+		next = result.Next
+	}
+	if want := []string{"op8", "op9"}; !slices.Equal(found, want) {
+		t.Errorf("resumed pages found %v, want %v", found, want)
+	}
+}
+
+func TestParseOperationsCursorRoundTrip(t *testing.T) {
+	cursor := NewOperationsCursor(minutely("op", 2, noon), nil, 4, 20)
+	got, err := ParseOperationsCursor(cursor.Encode())
+	if err != nil {
+		t.Fatalf("ParseOperationsCursor() error = %v", err)
+	}
+	if !got.ExecutedAt.Equal(cursor.ExecutedAt) || !slices.Equal(got.IDs, cursor.IDs) || got.Page != 4 || got.PerPage != 20 {
+		t.Errorf("ParseOperationsCursor() = %+v, want %+v", got, cursor)
+	}
+	for _, value := range []string{"", "not base64!", "e30"} {
+		if _, err := ParseOperationsCursor(value); err == nil {
+			t.Errorf("ParseOperationsCursor(%q) succeeded, want an error", value)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package domain
+
+import (
+	"maps"
+	"slices"
+)
+
+// OperationType is the kind of an operation.
+// Values outside the known set are kept as they are.
+type OperationType string
+
+// Known operation types.
+const (
+	OperationTypeCardTransaction OperationType = "card_transaction"
+	OperationTypeTopUp           OperationType = "top_up"
+	OperationTypeRollover        OperationType = "rollover"
+	OperationTypeRolloverTopUp   OperationType = "rollover_top_up"
+	OperationTypeRefund          OperationType = "refund"
+	OperationTypeReimbursement   OperationType = "reimbursement"
+	OperationTypeExpiration      OperationType = "expiration"
+	OperationTypeAdjustment      OperationType = "adjustment"
+)
+
+// KnownOperationTypes lists the operation types the server knows about. They were observed in the operations
+// returned by the Coverflex API, which does not publish its list, so other types may appear.
+var KnownOperationTypes = []OperationType{
+	OperationTypeCardTransaction,
+	OperationTypeTopUp,
+	OperationTypeRollover,
+	OperationTypeRolloverTopUp,
+	OperationTypeRefund,
+	OperationTypeReimbursement,
+	OperationTypeExpiration,
+	OperationTypeAdjustment,
+}
+
+// IsKnown reports whether the type is one of KnownOperationTypes.
+func (t OperationType) IsKnown() bool {
+	return slices.Contains(KnownOperationTypes, t)
+}
+
+// OperationStatus is the processing state of an operation.
+// Values outside the known set are kept as they are.
+type OperationStatus string
+
+// Known operation statuses.
+const (
+	OperationStatusPending   OperationStatus = "pending"
+	OperationStatusConfirmed OperationStatus = "confirmed"
+	OperationStatusCompleted OperationStatus = "completed"
+	OperationStatusDeclined  OperationStatus = "declined"
+	OperationStatusCancelled OperationStatus = "cancelled"
+	OperationStatusReversed  OperationStatus = "reversed"
+	OperationStatusFailed    OperationStatus = "failed"
+)
+
+// KnownOperationStatuses lists the operation statuses the server knows about. Like KnownOperationTypes,
+// they were observed in the API responses and are not exhaustive.
+var KnownOperationStatuses = []OperationStatus{
+	OperationStatusPending,
+	OperationStatusConfirmed,
+	OperationStatusCompleted,
+	OperationStatusDeclined,
+	OperationStatusCancelled,
+	OperationStatusReversed,
+	OperationStatusFailed,
+}
+
+// IsKnown reports whether the status is one of KnownOperationStatuses.
+func (s OperationStatus) IsKnown() bool {
+	return slices.Contains(KnownOperationStatuses, s)
+}
+
+// CategorySlug identifies the benefit category an operation belongs to.
+// Values outside the known set are kept as they are.
+type CategorySlug string
+
+// Known benefit categories.
+const (
+	CategoryMeal          CategorySlug = "meal"
+	CategoryHealth        CategorySlug = "health"
+	CategoryEducation     CategorySlug = "education"
+	CategoryNursery       CategorySlug = "nursery"
+	CategoryTransports    CategorySlug = "transports"
+	CategoryCulture       CategorySlug = "culture"
+	CategoryWellness      CategorySlug = "wellness"
+	CategoryRetirement    CategorySlug = "retirement_savings"
+	CategoryInsurance     CategorySlug = "insurance"
+	CategoryPets          CategorySlug = "pets"
+	CategoryHomeOffice    CategorySlug = "home_office"
+	CategoryGeneralBudget CategorySlug = "general_budget"
+)
+
+// KnownCategorySlugs lists the benefit categories the server knows about: the categories of the benefits
+// offered in the Coverflex app, as observed in the API responses. Other categories may appear.
+var KnownCategorySlugs = []CategorySlug{
+	CategoryMeal,
+	CategoryHealth,
+	CategoryEducation,
+	CategoryNursery,
+	CategoryTransports,
+	CategoryCulture,
+	CategoryWellness,
+	CategoryRetirement,
+	CategoryInsurance,
+	CategoryPets,
+	CategoryHomeOffice,
+	CategoryGeneralBudget,
+}
+
+// IsKnown reports whether the category is one of KnownCategorySlugs.
+func (c CategorySlug) IsKnown() bool {
+	return slices.Contains(KnownCategorySlugs, c)
+}
+
+// EnumValue is a value of an operation enum, with whether it is in the known set and how often it was observed.
+type EnumValue struct {
+	Value    string `json:"value"`
+	Known    bool   `json:"known"`
+	Observed int    `json:"observed"`
+}
+
+// OperationVocabulary gathers the operation types, statuses and categories, merging the known set with
+// the values observed in actual operations.
+type OperationVocabulary struct {
+	types      map[OperationType]int
+	statuses   map[OperationStatus]int
+	categories map[CategorySlug]int
+}
+
+// NewOperationVocabulary returns a vocabulary holding the known values, none of them observed yet.
+func NewOperationVocabulary() *OperationVocabulary {
+	v := &OperationVocabulary{
+		types:      make(map[OperationType]int),
+		statuses:   make(map[OperationStatus]int),
+		categories: make(map[CategorySlug]int),
+	}
+	for _, t := range KnownOperationTypes {
+		v.types[t] = 0
+	}
+	for _, s := range KnownOperationStatuses {
+		v.statuses[s] = 0
+	}
+	for _, c := range KnownCategorySlugs {
+		v.categories[c] = 0
+	}
+	return v
+}
+
+// Observe records the values of an operation. Empty values are ignored.
+func (v *OperationVocabulary) Observe(operationType OperationType, status OperationStatus, category CategorySlug) {
+	if operationType != "" {
+		v.types[operationType]++
+	}
+	if status != "" {
+		v.statuses[status]++
+	}
+	if category != "" {
+		v.categories[category]++
+	}
+}
+
+// Types returns the operation types, most observed first.
+func (v *OperationVocabulary) Types() []EnumValue {
+	return enumValues(v.types, OperationType.IsKnown)
+}
+
+// Statuses returns the operation statuses, most observed first.
+func (v *OperationVocabulary) Statuses() []EnumValue {
+	return enumValues(v.statuses, OperationStatus.IsKnown)
+}
+
+// Categories returns the benefit categories, most observed first.
+func (v *OperationVocabulary) Categories() []EnumValue {
+	return enumValues(v.categories, CategorySlug.IsKnown)
+}
+
+func enumValues[T ~string](counts map[T]int, isKnown func(T) bool) []EnumValue {
+	values := make([]EnumValue, 0, len(counts))
+	for _, value := range slices.Sorted(maps.Keys(counts)) {
+		values = append(values, EnumValue{
+			Value:    string(value),
+			Known:    isKnown(value),
+			Observed: counts[value],
+		})
+	}
+	slices.SortStableFunc(values, func(a, b EnumValue) int {
+		return b.Observed - a.Observed
+	})
+	return values
+}
+
+// EnumStrings converts enum values to plain strings, e.g. to publish them in a schema.
+func EnumStrings[T ~string](values []T) []string {
+	strings := make([]string, 0, len(values))
+	for _, value := range values {
+		strings = append(strings, string(value))
+	}
+	return strings
+}
@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// ValueFilter keeps the values that are one of Include, if any, and none of Exclude.
+type ValueFilter[T ~string] struct {
+	Include []T
+	Exclude []T
+}
+
+// ParseValueFilter parses a comma separated list of values, where the values prefixed with '!' are excluded,
+// e.g. "meal,health" or "!declined,!cancelled".
+func ParseValueFilter[T ~string](value string) ValueFilter[T] {
+	var filter ValueFilter[T]
+	for part := range strings.SplitSeq(value, ",") {
+		part = strings.TrimSpace(part)
+		if excluded, ok := strings.CutPrefix(part, "!"); ok {
+			if excluded = strings.TrimSpace(excluded); excluded != "" {
+				filter.Exclude = append(filter.Exclude, T(excluded))
+			}
+		} else if part != "" {
+			filter.Include = append(filter.Include, T(part))
+		}
+	}
+	return filter
+}
+
+// IsZero reports whether the filter keeps every value.
+func (f ValueFilter[T]) IsZero() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0
+}
+
+// Single returns the only value the filter keeps, if it is exactly one value without exclusions.
+func (f ValueFilter[T]) Single() (T, bool) {
+	if len(f.Include) != 1 || len(f.Exclude) != 0 {
+		var zero T
+		return zero, false
+	}
+	return f.Include[0], true
+}
+
+// Matches reports whether the value is kept by the filter. Values are compared case-insensitively.
+func (f ValueFilter[T]) Matches(value T) bool {
+	return f.MatchesFunc(value, func(a, b T) bool { return strings.EqualFold(string(a), string(b)) })
+}
+
+// MatchesFunc reports whether the value is kept by the filter, comparing the values with equal.
+func (f ValueFilter[T]) MatchesFunc(value T, equal func(value, candidate T) bool) bool {
+	matches := func(candidate T) bool { return equal(value, candidate) }
+	if slices.ContainsFunc(f.Exclude, matches) {
+		return false
+	}
+	return len(f.Include) == 0 || slices.ContainsFunc(f.Include, matches)
+}
+
+// AmountRange keeps the amounts between Min and Max, both inclusive. A nil end leaves that side unbounded.
+// Amounts are compared in absolute value, as debits and credits are told apart by IsDebit.
+type AmountRange struct {
+	Min *Money
+	Max *Money
+}
+
+// IsZero reports whether the range keeps every amount.
+func (r AmountRange) IsZero() bool {
+	return r.Min == nil && r.Max == nil
+}
+
+// Validate fails if the range ends before it starts.
+func (r AmountRange) Validate() error {
+	if r.Min == nil || r.Max == nil {
+		return nil
+	}
+	cmp, err := r.Min.Cmp(*r.Max)
+	if err != nil {
+		return err
+	}
+	if cmp > 0 {
+		return errors.New("invalid amount range: the minimum is above the maximum")
+	}
+	return nil
+}
+
+// Contains reports whether the absolute amount is within the range. Amounts in another currency are not.
+func (r AmountRange) Contains(amount Money) bool {
+	amount = amount.Abs()
+	if r.Min != nil {
+		if cmp, err := amount.Cmp(*r.Min); err != nil || cmp < 0 {
+			return false
+		}
+	}
+	if r.Max != nil {
+		if cmp, err := amount.Cmp(*r.Max); err != nil || cmp > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// OperationsFilters holds the filter parameters for GetOperations. Every filter must pass for an
+// operation to be kept. The API only filters by a single type; the rest is applied client-side
+// while walking the pages.
+type OperationsFilters struct {
+	Types      ValueFilter[OperationType]
+	Categories ValueFilter[CategorySlug]
+	Products   ValueFilter[string]
+	Statuses   ValueFilter[OperationStatus]
+	// Merchants keeps the operations whose merchant name contains one of the values, ignoring case and accents.
+	Merchants ValueFilter[string]
+	// IsDebit keeps only debits or only credits when set.
+	IsDebit *bool
+	Amount  AmountRange
+	Period  DateRange
+}
+
+// ServerSideType returns the type the API can filter by, if the type filter is a single value.
+func (f OperationsFilters) ServerSideType() (OperationType, bool) {
+	return f.Types.Single()
+}
+
+// HasClientSide reports whether some filter must be applied client-side.
+func (f OperationsFilters) HasClientSide() bool {
+	if _, ok := f.ServerSideType(); !ok && !f.Types.IsZero() {
+		return true
+	}
+	return !f.Categories.IsZero() || !f.Products.IsZero() || !f.Statuses.IsZero() || !f.Merchants.IsZero() ||
+		f.IsDebit != nil || !f.Amount.IsZero() || !f.Period.IsZero()
+}
+
+// Matches reports whether the operation passes every filter.
+func (f OperationsFilters) Matches(operation Operation) bool {
+	return f.Types.Matches(operation.Type) &&
+		f.Categories.Matches(operation.CategorySlug) &&
+		f.Products.Matches(operation.ProductSlug) &&
+		f.Statuses.Matches(operation.Status) &&
+		f.Merchants.MatchesFunc(operation.MerchantName, containsFolded) &&
+		(f.IsDebit == nil || operation.IsDebit == *f.IsDebit) &&
+		f.Amount.Contains(operation.Amount) &&
+		f.Period.Contains(operation.ExecutedAt.CalendarDate())
+}
+
+// IsPast reports whether the operation is older than the period. As operations come most recent first,
+// no later operation can match either.
+func (f OperationsFilters) IsPast(operation Operation) bool {
+	return !f.Period.From.IsZero() && operation.ExecutedAt.CalendarDate().Before(f.Period.From)
+}
+
+// containsFolded reports whether text contains part, ignoring case and accents.
+func containsFolded(text, part string) bool {
+	return strings.Contains(FoldText(text), FoldText(part))
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// CounterpartWindowDays is how far apart an operation and its counterparts are looked for.
+const CounterpartWindowDays = 90
+
+// Relations between an operation and its counterpart.
+const (
+	RelationRefund        = "refund"
+	RelationRefundOf      = "refund_of"
+	RelationRolloverTopUp = "rollover_top_up"
+	RelationRolloverOf    = "rollover_of"
+)
+
+// OperationLink is an operation related to another one, such as the refund of a payment.
+type OperationLink struct {
+	// Relation is what the operation is to the one it is linked from, e.g. its refund.
+	Relation  string    `json:"relation"`
+	Operation Operation `json:"operation"`
+}
+
+// CounterpartWindow returns the period around the operation its counterparts are looked for in.
+func CounterpartWindow(operation Operation) DateRange {
+	date := operation.ExecutedAt.CalendarDate()
+	return DateRange{From: date.AddDate(0, 0, -CounterpartWindowDays), To: date.AddDate(0, 0, CounterpartWindowDays)}
+}
+
+// FindCounterparts returns the candidates linked to the operation: the refunds of a payment or the payment
+// a refund is for, as linked by LinkRefunds, and the two sides of a rollover, matched by amount.
+// The operation and the candidates are expected to be linked already.
+func FindCounterparts(operation Operation, candidates []Operation) []OperationLink {
+	links := []OperationLink{}
+	window := time.Duration(CounterpartWindowDays) * 24 * time.Hour
+	for _, candidate := range candidates {
+		if candidate.ID == operation.ID {
+			continue
+		}
+		var relation string
+		switch gap := candidate.ExecutedAt.Sub(operation.ExecutedAt.Time); {
+		case slices.Contains(operation.Refunds, candidate.ID):
+			relation = RelationRefund
+		case operation.RefundOf == candidate.ID:
+			relation = RelationRefundOf
+		case gap.Abs() > window || operation.Amount.Abs() != candidate.Amount.Abs():
+			continue
+		case operation.Type == OperationTypeRollover && candidate.Type == OperationTypeRolloverTopUp && gap >= 0:
+			relation = RelationRolloverTopUp
+		case operation.Type == OperationTypeRolloverTopUp && candidate.Type == OperationTypeRollover && gap <= 0:
+			relation = RelationRolloverOf
+		default:
+			continue
+		}
+		links = append(links, OperationLink{Relation: relation, Operation: candidate})
+	}
+	return links
+}
+
+// FindBenefit returns the benefit the operation belongs to, by category, and its product, if any.
+// The product is looked for in every benefit; its own benefit is returned when the category matches none.
+func FindBenefit(benefits []Benefit, operation Operation) (*Benefit, *Product) {
+	var benefit *Benefit
+	if index := slices.IndexFunc(benefits, func(b Benefit) bool { return b.Slug == string(operation.CategorySlug) }); index >= 0 {
+		benefit = &benefits[index]
+	}
+	if operation.ProductSlug == "" {
+		return benefit, nil
+	}
+	for i := range benefits {
+		for j := range benefits[i].Products {
+			if benefits[i].Products[j].Slug == operation.ProductSlug {
+				if benefit == nil {
+					benefit = &benefits[i]
+				}
+				return benefit, &benefits[i].Products[j]
+			}
+		}
+	}
+	return benefit, nil
+}
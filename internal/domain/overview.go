@@ -0,0 +1,49 @@
+package domain
+
+// Overview combines every section of the employee account.
+// A section is nil when fetching it failed; the reason is reported in Errors, keyed by section name.
+type Overview struct {
+	Company      *Company          `json:"company,omitempty"`
+	Compensation *Compensation     `json:"compensation,omitempty"`
+	Benefits     []Benefit         `json:"benefits,omitempty"`
+	Cards        []Card            `json:"cards,omitempty"`
+	Family       []FamilyMember    `json:"family,omitempty"`
+	Operations   []Operation       `json:"operations,omitempty"`
+	Errors       map[string]string `json:"errors,omitempty"`
+}
+
+// SetError records the error of a section.
+func (o *Overview) SetError(section string, err error) {
+	if o.Errors == nil {
+		o.Errors = make(map[string]string)
+	}
+	o.Errors[section] = err.Error()
+}
+
+// DefaultOverviewParallelism is the number of sections fetched at the same time by default.
+const DefaultOverviewParallelism = 3
+
+// GetOverviewParams holds the parameters for the GetOverview method.
+type GetOverviewParams struct {
+	Parallelism       int
+	OperationsOptions []GetOperationsOption
+}
+
+// GetOverviewOption defines a function that modifies GetOverviewParams.
+type GetOverviewOption func(*GetOverviewParams)
+
+// WithOverviewParallelism sets the maximum number of sections fetched concurrently.
+func WithOverviewParallelism(parallelism int) GetOverviewOption {
+	return func(params *GetOverviewParams) {
+		if parallelism > 0 {
+			params.Parallelism = parallelism
+		}
+	}
+}
+
+// WithOverviewOperations sets the options used to fetch the operations section.
+func WithOverviewOperations(opts ...GetOperationsOption) GetOverviewOption {
+	return func(params *GetOverviewParams) {
+		params.OperationsOptions = opts
+	}
+}
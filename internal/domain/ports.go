@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"context"
+	"iter"
+)
+
+// The ports below are implemented by the Coverflex API client and consumed by the MCP tools,
+// so the tools can be wired to fakes, caches or decorators.
+
+// Session reports the state of the user session.
+type Session interface {
+	// IsLoggedIn reports whether the user has a session.
+	IsLoggedIn() bool
+	// Locale returns the language the session content is retrieved in.
+	Locale(ctx context.Context) string
+}
+
+// Authenticator logs the user in through the OTP flow.
+type Authenticator interface {
+	Session
+	RequestOTP(ctx context.Context, email, password string) error
+	Login(ctx context.Context, email, password, otp string) error
+}
+
+// BenefitsReader retrieves the employee benefits.
+type BenefitsReader interface {
+	Session
+	GetBenefits(ctx context.Context) ([]Benefit, error)
+}
+
+// CardsReader retrieves the employee cards.
+type CardsReader interface {
+	Session
+	GetCards(ctx context.Context) ([]Card, error)
+}
+
+// CompanyReader retrieves the employee company.
+type CompanyReader interface {
+	Session
+	GetCompany(ctx context.Context) (*Company, error)
+}
+
+// CompensationReader retrieves the employee compensation summary.
+type CompensationReader interface {
+	Session
+	GetCompensation(ctx context.Context) (*Compensation, error)
+}
+
+// FamilyReader retrieves the employee family members.
+type FamilyReader interface {
+	Session
+	GetFamily(ctx context.Context) ([]FamilyMember, error)
+}
+
+// OperationsReader retrieves the employee operations.
+type OperationsReader interface {
+	Session
+	GetOperations(ctx context.Context, opts ...GetOperationsOption) ([]Operation, error)
+}
+
+// OperationsCursorReader retrieves the employee operations a page at a time from a stable position,
+// so new operations arriving between calls cause no duplicates.
+type OperationsCursorReader interface {
+	Session
+	// GetOperationsAfter returns the page of operations right after the cursor, or the first page if it is nil.
+	GetOperationsAfter(ctx context.Context, cursor *OperationsCursor, opts ...GetOperationsOption) (*OperationsCursorPage, error)
+}
+
+// OperationReader retrieves a single employee operation by ID.
+type OperationReader interface {
+	Session
+	// GetOperation returns the operation, or an error wrapping ErrOperationNotFound if there is none.
+	GetOperation(ctx context.Context, id string) (*Operation, error)
+}
+
+// OverviewReader retrieves every section of the employee account at once.
+type OverviewReader interface {
+	Session
+	GetOverview(ctx context.Context, opts ...GetOverviewOption) *Overview
+}
+
+// CardStatusReader retrieves the employee cards together with the company card policies.
+type CardStatusReader interface {
+	CardsReader
+	CompanyReader
+}
+
+// FamilyInsightsReader retrieves the employee family members together with the benefits they may use.
+type FamilyInsightsReader interface {
+	FamilyReader
+	BenefitsReader
+}
+
+// OperationsIterator iterates over every employee operation, walking the pages on demand.
+type OperationsIterator interface {
+	Session
+	AllOperations(ctx context.Context, opts ...GetOperationsOption) iter.Seq2[Operation, error]
+}
+
+// OperationsPeriodReader retrieves the employee operations, resolving the benefit period from the compensation.
+type OperationsPeriodReader interface {
+	OperationsReader
+	CompensationReader
+}
+
+// OperationsPagesReader retrieves the employee operations by page number or cursor, resolving the benefit
+// period from the compensation.
+type OperationsPagesReader interface {
+	OperationsPeriodReader
+	OperationsCursorReader
+}
+
+// OperationsPeriodIterator iterates over the employee operations, resolving the benefit period from the compensation.
+type OperationsPeriodIterator interface {
+	OperationsIterator
+	CompensationReader
+}
+
+// BenefitUtilizationReader retrieves the employee benefits together with the operations spent on them,
+// resolving the benefit year from the compensation.
+type BenefitUtilizationReader interface {
+	OperationsPeriodIterator
+	BenefitsReader
+}
+
+// OperationsSyncer syncs the local operations ledger with the API.
+type OperationsSyncer interface {
+	Session
+	// SyncOperations fetches the operations since the last sync, or the whole history if full is set
+	// or the ledger is incomplete, walking at most maxPages pages (0 means no limit).
+	SyncOperations(ctx context.Context, full bool, maxPages int) (*LedgerSyncResult, error)
+}
+
+// OperationDetailReader retrieves an operation together with the benefits and operations it is related to.
+type OperationDetailReader interface {
+	OperationReader
+	OperationsIterator
+	BenefitsReader
+}
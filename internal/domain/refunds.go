@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// RefundWindowDays is how long after a payment a credit may still be its refund.
+const RefundWindowDays = 90
+
+// IsRefundCandidate reports whether the operation is a credit that may refund a payment: a refund or a
+// card transaction going back into the account.
+func (o Operation) IsRefundCandidate() bool {
+	return !o.IsDebit && (o.Type == OperationTypeRefund || o.Type == OperationTypeCardTransaction)
+}
+
+// NetAmount returns the absolute amount the operation counts towards spending: a payment minus what was
+// refunded of it, nothing for a credit linked as the refund of a payment, and the amount otherwise.
+func (o Operation) NetAmount() Money {
+	if o.RefundOf != "" {
+		return NewMoney(0, o.Amount.Currency)
+	}
+	return o.refundableAmount()
+}
+
+// refundableAmount returns how much of the payment is left to refund.
+func (o Operation) refundableAmount() Money {
+	remaining, err := o.Amount.Abs().Sub(o.refundedOrZero())
+	if err != nil || remaining.IsNegative() {
+		return NewMoney(0, o.Amount.Currency)
+	}
+	return remaining
+}
+
+func (o Operation) refundedOrZero() Money {
+	if o.RefundedAmount == nil {
+		return NewMoney(0, o.Amount.Currency)
+	}
+	return *o.RefundedAmount
+}
+
+// LinkRefunds returns a copy of the operations with the refunds linked to the payments they refund, through
+// Refunds, RefundedAmount and RefundOf. A credit is linked to the payment its description params reference,
+// or else to the earlier payment at the same merchant within RefundWindowDays with enough left to refund,
+// preferring the exact amount, the same category and the closest in time. Existing links are kept.
+func LinkRefunds(operations []Operation) []Operation {
+	linked := slices.Clone(operations)
+	for i := range linked {
+		linked[i].Refunds = slices.Clone(linked[i].Refunds)
+	}
+
+	// The credits are linked oldest first, so earlier refunds use up a payment before later ones.
+	credits := make([]int, 0, len(linked))
+	for i, operation := range linked {
+		if operation.IsRefundCandidate() && operation.RefundOf == "" {
+			credits = append(credits, i)
+		}
+	}
+	slices.SortStableFunc(credits, func(a, b int) int {
+		return linked[a].ExecutedAt.Compare(linked[b].ExecutedAt.Time)
+	})
+
+	for _, c := range credits {
+		best, bestScore := -1, 0.0
+		for d := range linked {
+			if score, ok := refundScore(linked[c], linked[d]); ok && (best < 0 || score > bestScore) {
+				best, bestScore = d, score
+			}
+		}
+		if best < 0 {
+			continue
+		}
+		credit, payment := &linked[c], &linked[best]
+		refunded, err := SumMoney(payment.refundedOrZero(), credit.Amount.Abs())
+		if err != nil {
+			continue
+		}
+		credit.RefundOf = payment.ID
+		payment.Refunds = append(payment.Refunds, credit.ID)
+		payment.RefundedAmount = &refunded
+	}
+	return linked
+}
+
+// refundScore rates how likely the credit is to refund the payment, and reports whether it can at all.
+func refundScore(credit, payment Operation) (float64, bool) {
+	if !payment.IsDebit || payment.ID == credit.ID {
+		return 0, false
+	}
+	if references(credit, payment.ID) {
+		return 100, true
+	}
+
+	gap := credit.ExecutedAt.Sub(payment.ExecutedAt.Time)
+	if gap < 0 || gap > RefundWindowDays*24*time.Hour {
+		return 0, false
+	}
+	if payment.Type != OperationTypeCardTransaction || credit.MerchantName == "" ||
+		FoldText(credit.MerchantName) != FoldText(payment.MerchantName) {
+		return 0, false
+	}
+	remaining := payment.refundableAmount()
+	c, err := credit.Amount.Abs().Cmp(remaining)
+	if err != nil || c > 0 {
+		return 0, false
+	}
+
+	score := 1 - gap.Hours()/(RefundWindowDays*24)
+	if c == 0 {
+		score += 10
+	}
+	if credit.CategorySlug == payment.CategorySlug {
+		score += 5
+	}
+	return score, true
+}
+
+// references reports whether one of the description params of the operation is the given operation ID.
+func references(operation Operation, id string) bool {
+	return slices.ContainsFunc(operation.DescriptionParams, func(param DescriptionParam) bool {
+		return param.Value != nil && fmt.Sprint(param.Value) == id
+	})
+}
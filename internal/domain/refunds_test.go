@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestLinkRefunds(t *testing.T) {
+	day := func(d int) Timestamp {
+		return NewTimestamp(time.Date(2025, time.March, d, 12, 0, 0, 0, time.UTC))
+	}
+	payment := func(id string, d int, merchant string, cents int64) Operation {
+		return Operation{ID: id, Amount: NewMoney(-cents, DefaultCurrency), ExecutedAt: day(d), IsDebit: true,
+			MerchantName: merchant, CategorySlug: CategoryMeal, Type: OperationTypeCardTransaction}
+	}
+	refund := func(id string, d int, merchant string, cents int64) Operation {
+		return Operation{ID: id, Amount: NewMoney(cents, DefaultCurrency), ExecutedAt: day(d),
+			MerchantName: merchant, CategorySlug: CategoryMeal, Type: OperationTypeRefund}
+	}
+	referencing := func(operation Operation, id string) Operation {
+		operation.DescriptionParams = []DescriptionParam{{Key: "transaction_id", Value: id}}
+		return operation
+	}
+
+	tests := []struct {
+		name       string
+		operations []Operation
+		// links maps each refund to the payment it should be linked to, or "" if none.
+		links map[string]string
+		// net is the amount each payment should still count towards spending, in cents.
+		net map[string]int64
+	}{
+		{
+			name:       "an exact refund nets the payment out",
+			operations: []Operation{refund("r", 5, "Pingo Doce", 1250), payment("p", 2, "Pingo Doce", 1250)},
+			links:      map[string]string{"r": "p"},
+			net:        map[string]int64{"p": 0, "r": 0},
+		},
+		{
+			name: "partial refunds add up on the payment",
+			operations: []Operation{
+				refund("r2", 8, "Continente", 500), refund("r1", 4, "Continente", 1000), payment("p", 1, "Continente", 3000),
+			},
+			links: map[string]string{"r1": "p", "r2": "p"},
+			net:   map[string]int64{"p": 1500},
+		},
+		{
+			name: "a refund larger than what is left of the payment stays unlinked",
+			operations: []Operation{
+				refund("r2", 8, "Continente", 2500), refund("r1", 4, "Continente", 1000), payment("p", 1, "Continente", 3000),
+			},
+			links: map[string]string{"r1": "p", "r2": ""},
+			net:   map[string]int64{"p": 2000, "r2": 2500},
+		},
+		{
+			name: "the exact amount is preferred over the closest payment",
+			operations: []Operation{
+				refund("r", 9, "Pingo Doce", 800), payment("near", 8, "Pingo Doce", 2000), payment("exact", 3, "Pingo Doce", 800),
+			},
+			links: map[string]string{"r": "exact"},
+			net:   map[string]int64{"near": 2000, "exact": 0},
+		},
+		{
+			name:       "another merchant is not refunded",
+			operations: []Operation{refund("r", 5, "Continente", 1250), payment("p", 2, "Pingo Doce", 1250)},
+			links:      map[string]string{"r": ""},
+			net:        map[string]int64{"p": 1250},
+		},
+		{
+			name:       "a payment after the refund is not refunded",
+			operations: []Operation{payment("p", 6, "Pingo Doce", 1250), refund("r", 5, "Pingo Doce", 1250)},
+			links:      map[string]string{"r": ""},
+		},
+		{
+			name: "a payment out of the refund window is not refunded",
+			operations: []Operation{
+				refund("r", 5, "Pingo Doce", 1250),
+				{ID: "p", Amount: NewMoney(-1250, DefaultCurrency), IsDebit: true, MerchantName: "Pingo Doce",
+					ExecutedAt: NewTimestamp(day(5).AddDate(0, 0, -RefundWindowDays-1)), Type: OperationTypeCardTransaction},
+			},
+			links: map[string]string{"r": ""},
+		},
+		{
+			name: "a refund referencing a payment is linked to it whatever its merchant",
+			operations: []Operation{
+				referencing(refund("r", 5, "", 400), "p2"), payment("p1", 4, "Pingo Doce", 400), payment("p2", 1, "Farmácia", 1000),
+			},
+			links: map[string]string{"r": "p2"},
+			net:   map[string]int64{"p1": 400, "p2": 600},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			linked := LinkRefunds(tt.operations)
+			byID := make(map[string]Operation, len(linked))
+			for _, operation := range linked {
+				byID[operation.ID] = operation
+			}
+			for refundID, paymentID := range tt.links {
+				if got := byID[refundID].RefundOf; got != paymentID {
+					t.Errorf("%s.RefundOf = %q, want %q", refundID, got, paymentID)
+				}
+				if paymentID != "" && !slices.Contains(byID[paymentID].Refunds, refundID) {
+					t.Errorf("%s.Refunds = %v, want it to contain %s", paymentID, byID[paymentID].Refunds, refundID)
+				}
+			}
+			for id, cents := range tt.net {
+				if got := byID[id].NetAmount(); got != NewMoney(cents, DefaultCurrency) {
+					t.Errorf("%s.NetAmount() = %s, want %d cents", id, got, cents)
+				}
+			}
+			for i, operation := range tt.operations {
+				if operation.RefundOf != "" || operation.RefundedAmount != nil || linked[i].ID != operation.ID {
+					t.Errorf("LinkRefunds() changed its input or the order of the operations")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package domain
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// SpendingGranularity is the length of the periods spending is broken down by.
+type SpendingGranularity string
+
+// Spending granularities.
+const (
+	SpendingByDay   SpendingGranularity = "day"
+	SpendingByWeek  SpendingGranularity = "week"
+	SpendingByMonth SpendingGranularity = "month"
+)
+
+// SpendingGranularities lists the spending granularities, the default last.
+var SpendingGranularities = []SpendingGranularity{SpendingByDay, SpendingByWeek, SpendingByMonth}
+
+// ParseSpendingGranularity returns the granularity with the given name, or SpendingByMonth if it is empty.
+func ParseSpendingGranularity(name string) (SpendingGranularity, error) {
+	if name == "" {
+		return SpendingByMonth, nil
+	}
+	if !slices.Contains(SpendingGranularities, SpendingGranularity(name)) {
+		return "", fmt.Errorf("unknown granularity %q, expected one of %v", name, SpendingGranularities)
+	}
+	return SpendingGranularity(name), nil
+}
+
+// bucket returns the period of the given granularity the day falls in, and its key, e.g. "2025-03",
+// "2025-W10" or "2025-03-05".
+func (g SpendingGranularity) bucket(d Date) (string, DateRange) {
+	switch g {
+	case SpendingByDay:
+		return d.ISO(), DateRange{From: d, To: d}
+	case SpendingByWeek:
+		year, week := d.Time().ISOWeek()
+		monday := d.AddDate(0, 0, -(int(d.Time().Weekday())+6)%7)
+		return fmt.Sprintf("%d-W%02d", year, week), DateRange{From: monday, To: monday.AddDate(0, 0, 6)}
+	default:
+		first := NewDate(d.Year, d.Month, 1)
+		return fmt.Sprintf("%d-%02d", d.Year, int(d.Month)), DateRange{From: first, To: first.AddDate(0, 1, -1)}
+	}
+}
+
+// SpendingTotal is the amount spent net of refunds, and the number of payments it is made of.
+type SpendingTotal struct {
+	Total Money `json:"total"`
+	// Refunded is the amount of the refunds netted out of Total.
+	Refunded Money `json:"refunded"`
+	Payments int   `json:"payments"`
+}
+
+func newSpendingTotal() SpendingTotal {
+	return SpendingTotal{Total: NewMoney(0, DefaultCurrency), Refunded: NewMoney(0, DefaultCurrency)}
+}
+
+// add counts the spent and refunded amounts, and the operation as a payment if it is one.
+func (t *SpendingTotal) add(spent, refunded Money, payment bool) error {
+	total, err := t.Total.Add(spent)
+	if err != nil {
+		return err
+	}
+	if t.Refunded, err = t.Refunded.Add(refunded); err != nil {
+		return err
+	}
+	t.Total = total
+	if payment {
+		t.Payments++
+	}
+	return nil
+}
+
+// ProductSpending is the spending on a product of a category.
+type ProductSpending struct {
+	Product string `json:"product"`
+	SpendingTotal
+}
+
+// CategorySpending is the spending on a benefit category, broken down by product.
+type CategorySpending struct {
+	Category CategorySlug `json:"category"`
+	SpendingTotal
+	Products []ProductSpending `json:"products"`
+}
+
+// PeriodSpending is the spending in a day, week or month.
+type PeriodSpending struct {
+	// Period is the key of the period, e.g. "2025-03-05", "2025-W10" or "2025-03".
+	Period string `json:"period"`
+	From   Date   `json:"from"`
+	To     Date   `json:"to"`
+	SpendingTotal
+}
+
+// SpendingSummary is the spending over a date range, by category and product and by period.
+type SpendingSummary struct {
+	Range       DateRange           `json:"range"`
+	Granularity SpendingGranularity `json:"granularity"`
+	SpendingTotal
+	// ByCategory is sorted by total, the largest first.
+	ByCategory []CategorySpending `json:"by_category"`
+	// ByPeriod is sorted chronologically. Periods without spending are included when the range is bounded.
+	ByPeriod []PeriodSpending `json:"by_period"`
+}
+
+// SummarizeSpending adds up the payments executed within the range. The operations are expected to be
+// linked with LinkRefunds: a payment counts net of the refunds linked to it, and the refunds no payment
+// was found for are subtracted when they are executed. Rollovers and reversed or failed payments are left out.
+func SummarizeSpending(operations []Operation, r DateRange, granularity SpendingGranularity) (*SpendingSummary, error) {
+	summary := &SpendingSummary{Range: r, Granularity: granularity, SpendingTotal: newSpendingTotal()}
+	categories := make(map[CategorySlug]*CategorySpending)
+	products := make(map[CategorySlug]map[string]*ProductSpending)
+	periods := make(map[string]*PeriodSpending)
+
+	for _, operation := range operations {
+		date := operation.ExecutedAt.CalendarDate()
+		spent, refunded, payment, ok := spendingOf(operation)
+		if !ok || !r.Contains(date) {
+			continue
+		}
+
+		category, found := categories[operation.CategorySlug]
+		if !found {
+			category = &CategorySpending{Category: operation.CategorySlug, SpendingTotal: newSpendingTotal()}
+			categories[operation.CategorySlug] = category
+			products[operation.CategorySlug] = make(map[string]*ProductSpending)
+		}
+		product, found := products[operation.CategorySlug][operation.ProductSlug]
+		if !found {
+			product = &ProductSpending{Product: operation.ProductSlug, SpendingTotal: newSpendingTotal()}
+			products[operation.CategorySlug][operation.ProductSlug] = product
+		}
+		key, bounds := granularity.bucket(date)
+		period, found := periods[key]
+		if !found {
+			period = &PeriodSpending{Period: key, From: bounds.From, To: bounds.To, SpendingTotal: newSpendingTotal()}
+			periods[key] = period
+		}
+
+		for _, total := range []*SpendingTotal{&summary.SpendingTotal, &category.SpendingTotal, &product.SpendingTotal, &period.SpendingTotal} {
+			if err := total.add(spent, refunded, payment); err != nil {
+				return nil, fmt.Errorf("operation %s: %w", operation.ID, err)
+			}
+		}
+	}
+
+	for _, category := range categories {
+		for _, product := range products[category.Category] {
+			category.Products = append(category.Products, *product)
+		}
+		slices.SortFunc(category.Products, func(a, b ProductSpending) int {
+			return cmp.Or(cmp.Compare(b.Total.MinorUnits, a.Total.MinorUnits), cmp.Compare(a.Product, b.Product))
+		})
+		summary.ByCategory = append(summary.ByCategory, *category)
+	}
+	slices.SortFunc(summary.ByCategory, func(a, b CategorySpending) int {
+		return cmp.Or(cmp.Compare(b.Total.MinorUnits, a.Total.MinorUnits), cmp.Compare(a.Category, b.Category))
+	})
+
+	if !r.From.IsZero() && !r.To.IsZero() {
+		for d := r.From; !d.After(r.To); {
+			key, bounds := granularity.bucket(d)
+			if _, ok := periods[key]; !ok {
+				periods[key] = &PeriodSpending{Period: key, From: bounds.From, To: bounds.To, SpendingTotal: newSpendingTotal()}
+			}
+			d = bounds.To.AddDate(0, 0, 1)
+		}
+	}
+	for _, period := range periods {
+		summary.ByPeriod = append(summary.ByPeriod, *period)
+	}
+	slices.SortFunc(summary.ByPeriod, func(a, b PeriodSpending) int { return a.From.Compare(b.From) })
+
+	if summary.ByCategory == nil {
+		summary.ByCategory = []CategorySpending{}
+	}
+	if summary.ByPeriod == nil {
+		summary.ByPeriod = []PeriodSpending{}
+	}
+	return summary, nil
+}
+
+// spendingOf returns how the operation counts towards spending: the net amount spent, which is negative for
+// a refund no payment was found for, the amount refunded, and whether it is a payment. It reports false
+// for the operations that do not count.
+func spendingOf(operation Operation) (Money, Money, bool, bool) {
+	switch {
+	case operation.Type == OperationTypeRollover || operation.Type == OperationTypeRolloverTopUp:
+		return Money{}, Money{}, false, false
+	case operation.Status.IsReversal() || operation.Status == OperationStatusFailed:
+		return Money{}, Money{}, false, false
+	case operation.IsDebit:
+		return operation.NetAmount(), operation.refundedOrZero(), true, true
+	case operation.Type == OperationTypeRefund && operation.RefundOf == "":
+		return operation.Amount.Abs().Neg(), operation.Amount.Abs(), false, true
+	default:
+		return Money{}, Money{}, false, false
+	}
+}
+
+// SpendingPageSize is the number of operations requested per page when loading the spending.
+const SpendingPageSize = 50
+
+// SpendingWindow widens the range by RefundWindowDays on each bounded side, so the payments refunded within
+// the range and the refunds of the payments made within it are loaded too.
+func SpendingWindow(r DateRange) DateRange {
+	window := r
+	if !window.From.IsZero() {
+		window.From = window.From.AddDate(0, 0, -RefundWindowDays)
+	}
+	if !window.To.IsZero() {
+		window.To = window.To.AddDate(0, 0, RefundWindowDays)
+	}
+	return window
+}
+
+// LoadSpendingOperations walks the operations in the SpendingWindow of the range, at most maxPages pages with
+// 0 meaning no limit, and links their refunds. It reports whether the page limit left older operations out.
+func LoadSpendingOperations(ctx context.Context, source OperationsIterator, r DateRange, maxPages int) ([]Operation, bool, error) {
+	opts := []GetOperationsOption{WithOperationsPerPage(SpendingPageSize), WithOperationsPeriod(SpendingWindow(r))}
+	if maxPages > 0 {
+		opts = append(opts, WithOperationsMaxPages(maxPages))
+	}
+
+	var operations []Operation
+	for operation, err := range source.AllOperations(ctx, opts...) {
+		if errors.Is(err, ErrPageLimitReached) {
+			return LinkRefunds(operations), true, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		operations = append(operations, operation)
+	}
+	return LinkRefunds(operations), false, nil
+}
+
+// LoadSpending loads the operations with LoadSpendingOperations and summarizes the spending within the range.
+func LoadSpending(ctx context.Context, source OperationsIterator, r DateRange, granularity SpendingGranularity, maxPages int) (*SpendingSummary, bool, error) {
+	operations, incomplete, err := LoadSpendingOperations(ctx, source, r, maxPages)
+	if err != nil {
+		return nil, false, err
+	}
+	summary, err := SummarizeSpending(operations, r, granularity)
+	return summary, incomplete, err
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSpendingGranularityBucket(t *testing.T) {
+	tests := []struct {
+		granularity SpendingGranularity
+		day         Date
+		key         string
+		from, to    Date
+	}{
+		{SpendingByDay, NewDate(2025, time.March, 5), "2025-03-05", NewDate(2025, time.March, 5), NewDate(2025, time.March, 5)},
+		// ISO weeks start on Monday, and the first week of a year may start in the previous one.
+		{SpendingByWeek, NewDate(2025, time.March, 10), "2025-W11", NewDate(2025, time.March, 10), NewDate(2025, time.March, 16)},
+		{SpendingByWeek, NewDate(2025, time.March, 16), "2025-W11", NewDate(2025, time.March, 10), NewDate(2025, time.March, 16)},
+		{SpendingByWeek, NewDate(2024, time.December, 30), "2025-W01", NewDate(2024, time.December, 30), NewDate(2025, time.January, 5)},
+		{SpendingByWeek, NewDate(2021, time.January, 3), "2020-W53", NewDate(2020, time.December, 28), NewDate(2021, time.January, 3)},
+		{SpendingByMonth, NewDate(2024, time.February, 29), "2024-02", NewDate(2024, time.February, 1), NewDate(2024, time.February, 29)},
+		{SpendingByMonth, NewDate(2025, time.December, 1), "2025-12", NewDate(2025, time.December, 1), NewDate(2025, time.December, 31)},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.granularity)+" "+tt.day.ISO(), func(t *testing.T) {
+			key, bounds := tt.granularity.bucket(tt.day)
+			if key != tt.key || bounds.From != tt.from || bounds.To != tt.to {
+				t.Errorf("bucket() = %s %s..%s, want %s %s..%s", key, bounds.From, bounds.To, tt.key, tt.from, tt.to)
+			}
+		})
+	}
+}
+
+func TestSummarizeSpending(t *testing.T) {
+	at := func(month time.Month, day, hour int) Timestamp {
+		return NewTimestamp(time.Date(2025, month, day, hour, 30, 0, 0, time.UTC))
+	}
+	operations := LinkRefunds([]Operation{
+		// Late on the evening of March 31st in UTC is already April 1st in Lisbon.
+		{ID: "april", Amount: NewMoney(-700, DefaultCurrency), IsDebit: true, ExecutedAt: at(time.March, 31, 23),
+			CategorySlug: CategoryMeal, ProductSlug: "meal-card", Type: OperationTypeCardTransaction, MerchantName: "Cantina"},
+		{ID: "refund", Amount: NewMoney(500, DefaultCurrency), ExecutedAt: at(time.March, 20, 10),
+			CategorySlug: CategoryMeal, Type: OperationTypeRefund, MerchantName: "Pingo Doce"},
+		{ID: "orphan", Amount: NewMoney(300, DefaultCurrency), ExecutedAt: at(time.March, 15, 10),
+			CategorySlug: CategoryHealth, Type: OperationTypeRefund, MerchantName: "Farmácia"},
+		{ID: "paid", Amount: NewMoney(-2000, DefaultCurrency), IsDebit: true, ExecutedAt: at(time.March, 10, 10),
+			CategorySlug: CategoryMeal, ProductSlug: "meal-card", Type: OperationTypeCardTransaction, MerchantName: "Pingo Doce"},
+		{ID: "declined", Amount: NewMoney(-9000, DefaultCurrency), IsDebit: true, ExecutedAt: at(time.March, 9, 10),
+			CategorySlug: CategoryMeal, Type: OperationTypeCardTransaction, Status: OperationStatusDeclined},
+		{ID: "rollover", Amount: NewMoney(-4000, DefaultCurrency), IsDebit: true, ExecutedAt: at(time.March, 8, 10),
+			CategorySlug: CategoryMeal, Type: OperationTypeRollover},
+		{ID: "february", Amount: NewMoney(-1000, DefaultCurrency), IsDebit: true, ExecutedAt: at(time.February, 28, 10),
+			CategorySlug: CategoryHealth, Type: OperationTypeCardTransaction},
+	})
+
+	summary, err := SummarizeSpending(operations, DateRange{From: NewDate(2025, time.March, 1), To: NewDate(2025, time.April, 30)}, SpendingByMonth)
+	if err != nil {
+		t.Fatalf("SummarizeSpending() error = %v", err)
+	}
+
+	// 20,00 € paid less its 5,00 € refund, 7,00 € in April and a 3,00 € refund without its payment.
+	want := SpendingTotal{Total: NewMoney(1900, DefaultCurrency), Refunded: NewMoney(800, DefaultCurrency), Payments: 2}
+	if summary.SpendingTotal != want {
+		t.Errorf("total = %+v, want %+v", summary.SpendingTotal, want)
+	}
+
+	periods := map[string]int64{}
+	for _, period := range summary.ByPeriod {
+		periods[period.Period] = period.Total.MinorUnits
+	}
+	if len(summary.ByPeriod) != 2 || periods["2025-03"] != 1200 || periods["2025-04"] != 700 {
+		t.Errorf("by period = %v, want 2025-03 at 1200 and 2025-04 at 700 cents", periods)
+	}
+
+	if len(summary.ByCategory) != 2 || summary.ByCategory[0].Category != CategoryMeal || summary.ByCategory[1].Category != CategoryHealth {
+		t.Fatalf("by category = %+v, want meal then health", summary.ByCategory)
+	}
+	if meal := summary.ByCategory[0]; meal.Total != NewMoney(2200, DefaultCurrency) || len(meal.Products) != 1 || meal.Products[0].Payments != 2 {
+		t.Errorf("meal = %+v, want 2200 cents from two meal-card payments", meal)
+	}
+	if health := summary.ByCategory[1]; health.Total != NewMoney(-300, DefaultCurrency) || health.Payments != 0 {
+		t.Errorf("health = %+v, want -300 cents and no payments", health)
+	}
+}
+
+func TestSummarizeSpendingFillsEmptyPeriods(t *testing.T) {
+	summary, err := SummarizeSpending(nil, DateRange{From: NewDate(2025, time.March, 5), To: NewDate(2025, time.March, 18)}, SpendingByWeek)
+	if err != nil {
+		t.Fatalf("SummarizeSpending() error = %v", err)
+	}
+	var keys []string
+	for _, period := range summary.ByPeriod {
+		keys = append(keys, period.Period)
+	}
+	if want := []string{"2025-W10", "2025-W11", "2025-W12"}; !slices.Equal(keys, want) {
+		t.Errorf("periods = %v, want %v", keys, want)
+	}
+	if len(summary.ByCategory) != 0 || summary.ByCategory == nil {
+		t.Errorf("by category = %#v, want an empty list", summary.ByCategory)
+	}
+}
@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// accentFolding maps the accented Latin letters used in Portuguese, Spanish and French to their base letter.
+var accentFolding = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
+
+// FoldText normalizes text for comparison: lower case, without accents and with collapsed whitespace,
+// so "Pingo  Doce" and "pingo doce" or "Café" and "cafe" are equal.
+func FoldText(text string) string {
+	return strings.Join(strings.FieldsFunc(accentFolding.Replace(strings.ToLower(text)), unicode.IsSpace), " ")
+}
@@ -0,0 +1,267 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/invopop/jsonschema"
+)
+
+// DefaultDisplayTimezone is the timezone timestamps are displayed in unless configured otherwise.
+const DefaultDisplayTimezone = "Europe/Lisbon"
+
+var displayLocation atomic.Pointer[time.Location]
+
+// SetDisplayLocation sets the timezone used to display timestamps and to decide which day they belong to.
+func SetDisplayLocation(loc *time.Location) {
+	displayLocation.Store(loc)
+}
+
+// DisplayLocation returns the timezone used to display timestamps, Europe/Lisbon by default.
+func DisplayLocation() *time.Location {
+	if loc := displayLocation.Load(); loc != nil {
+		return loc
+	}
+	loc, err := time.LoadLocation(DefaultDisplayTimezone)
+	if err != nil {
+		return time.UTC
+	}
+	displayLocation.CompareAndSwap(nil, loc)
+	return loc
+}
+
+// timestampLayouts are the layouts accepted when decoding a Timestamp. Layouts without an offset are read as UTC.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// Timestamp is an instant in time, such as the moment an operation was executed.
+type Timestamp struct {
+	time.Time
+}
+
+// NewTimestamp wraps the given time.
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{Time: t}
+}
+
+// ParseTimestamp parses an ISO-8601 timestamp. Timestamps without an offset are read as UTC.
+func ParseTimestamp(value string) (Timestamp, error) {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return Timestamp{Time: t}, nil
+		}
+	}
+	return Timestamp{}, fmt.Errorf("invalid timestamp %q", value)
+}
+
+// InDisplayLocation returns the timestamp in the display timezone.
+func (t Timestamp) InDisplayLocation() time.Time {
+	return t.In(DisplayLocation())
+}
+
+// CalendarDate returns the calendar day of the timestamp in the display timezone.
+func (t Timestamp) CalendarDate() Date {
+	return DateOf(t.InDisplayLocation())
+}
+
+// ISO returns the timestamp in ISO-8601 format, with the offset of the display timezone.
+func (t Timestamp) ISO() string {
+	return t.InDisplayLocation().Format(time.RFC3339)
+}
+
+// Display returns the timestamp in a human-friendly format in the display timezone, e.g. "19 Oct 2026, 14:03 WEST".
+func (t Timestamp) Display() string {
+	return t.InDisplayLocation().Format("02 Jan 2006, 15:04 MST")
+}
+
+// String returns the timestamp in ISO-8601 format.
+func (t Timestamp) String() string {
+	return t.ISO()
+}
+
+type timestampJSON struct {
+	ISO     string `json:"iso"`
+	Display string `json:"display"`
+}
+
+// MarshalJSON encodes the timestamp with both its ISO-8601 and human-friendly forms.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(timestampJSON{ISO: t.ISO(), Display: t.Display()})
+}
+
+// UnmarshalJSON decodes the object produced by MarshalJSON, ignoring its display form.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	value, err := decodeTimeValue(data)
+	if err != nil || value == "" {
+		*t = Timestamp{}
+		return err
+	}
+	parsed, err := ParseTimestamp(value)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// JSONSchema describes the JSON produced by MarshalJSON, null for a zero timestamp.
+func (Timestamp) JSONSchema() *jsonschema.Schema {
+	return timeSchema("ISO-8601 timestamp with the offset of the display timezone, e.g. \"2026-10-19T14:03:00+01:00\".",
+		"Human-friendly timestamp in the display timezone, e.g. \"19 Oct 2026, 14:03 WEST\".")
+}
+
+// Date is a calendar day without time or timezone, such as a birth date or a renewal date.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// NewDate returns the given calendar day, normalizing out-of-range values like time.Date does.
+func NewDate(year int, month time.Month, day int) Date {
+	return DateOf(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// DateOf returns the calendar day of t in its own location.
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// Today returns the current calendar day in the display timezone.
+func Today() Date {
+	return DateOf(time.Now().In(DisplayLocation()))
+}
+
+// ParseDate parses a calendar day in "2006-01-02" format. It also accepts full timestamps, keeping
+// their day in the display timezone. Months are not days, so they are only accepted by ParseDateRange.
+func ParseDate(value string) (Date, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return DateOf(t), nil
+	}
+	if t, err := ParseTimestamp(value); err == nil {
+		return t.CalendarDate(), nil
+	}
+	return Date{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", value)
+}
+
+// IsZero reports whether the date is unset.
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// In returns the start of the day in the given location.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// Time returns the start of the day in the display timezone.
+func (d Date) Time() time.Time {
+	return d.In(DisplayLocation())
+}
+
+// AddDate returns the date shifted by the given years, months and days.
+func (d Date) AddDate(years, months, days int) Date {
+	return DateOf(d.In(time.UTC).AddDate(years, months, days))
+}
+
+// Before reports whether d is before other.
+func (d Date) Before(other Date) bool {
+	return d.Compare(other) < 0
+}
+
+// After reports whether d is after other.
+func (d Date) After(other Date) bool {
+	return d.Compare(other) > 0
+}
+
+// Compare returns -1, 0 or +1 depending on whether d is before, equal to or after other.
+func (d Date) Compare(other Date) int {
+	return d.In(time.UTC).Compare(other.In(time.UTC))
+}
+
+// DaysUntil returns the number of days from d to other, negative if other is before d.
+func (d Date) DaysUntil(other Date) int {
+	return int(other.In(time.UTC).Sub(d.In(time.UTC)).Hours() / 24)
+}
+
+// ISO returns the date in ISO-8601 format, e.g. "2026-10-19".
+func (d Date) ISO() string {
+	return d.In(time.UTC).Format(time.DateOnly)
+}
+
+// Display returns the date in a human-friendly format, e.g. "19 Oct 2026".
+func (d Date) Display() string {
+	return d.In(time.UTC).Format("02 Jan 2006")
+}
+
+// String returns the date in ISO-8601 format.
+func (d Date) String() string {
+	return d.ISO()
+}
+
+// MarshalJSON encodes the date with both its ISO-8601 and human-friendly forms.
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(timestampJSON{ISO: d.ISO(), Display: d.Display()})
+}
+
+// UnmarshalJSON decodes the object produced by MarshalJSON, ignoring its display form.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	value, err := decodeTimeValue(data)
+	if err != nil || value == "" {
+		*d = Date{}
+		return err
+	}
+	parsed, err := ParseDate(value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// JSONSchema describes the JSON produced by MarshalJSON, null for a zero date.
+func (Date) JSONSchema() *jsonschema.Schema {
+	return timeSchema("ISO-8601 calendar date, e.g. \"2026-10-19\".", "Human-friendly date, e.g. \"19 Oct 2026\".")
+}
+
+// decodeTimeValue extracts the ISO-8601 value from the {"iso": ...} object produced by MarshalJSON.
+// It returns an empty string for null.
+func decodeTimeValue(data []byte) (string, error) {
+	var object *timestampJSON
+	if err := json.Unmarshal(data, &object); err != nil || object == nil {
+		return "", err
+	}
+	return strings.TrimSpace(object.ISO), nil
+}
+
+// timeSchema describes the object produced by MarshalJSON, or null for a zero value, such as a card that was
+// never activated.
+func timeSchema(isoDescription, displayDescription string) *jsonschema.Schema {
+	properties := jsonschema.NewProperties()
+	properties.Set("iso", &jsonschema.Schema{Type: "string", Description: isoDescription})
+	properties.Set("display", &jsonschema.Schema{Type: "string", Description: displayDescription})
+	return &jsonschema.Schema{
+		AnyOf: []*jsonschema.Schema{
+			{
+				Type:       "object",
+				Properties: properties,
+				Required:   []string{"iso", "display"},
+			},
+			{Type: "null", Description: "Unknown or not set."},
+		},
+	}
+}
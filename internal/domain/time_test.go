@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "2025-03-08T12:30:15Z", want: time.Date(2025, time.March, 8, 12, 30, 15, 0, time.UTC)},
+		{value: "2025-03-08T12:30:15.123456+01:00", want: time.Date(2025, time.March, 8, 11, 30, 15, 123456000, time.UTC)},
+		// Timestamps without an offset are UTC, not Lisbon time.
+		{value: "2025-07-08T12:30:15", want: time.Date(2025, time.July, 8, 12, 30, 15, 0, time.UTC)},
+		{value: "2025-07-08 12:30:15.5", want: time.Date(2025, time.July, 8, 12, 30, 15, 500000000, time.UTC)},
+		{value: "2025-07-08", wantErr: true},
+		{value: "08/07/2025 12:30", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseTimestamp(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTimestamp(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseTimestamp(%q) = %s, want %s", tt.value, got.UTC(), tt.want)
+			}
+		})
+	}
+}
+
+func TestTimestampInLisbon(t *testing.T) {
+	tests := []struct {
+		name    string
+		utc     time.Time
+		day     Date
+		iso     string
+		display string
+	}{
+		{
+			name:    "winter is UTC",
+			utc:     time.Date(2025, time.January, 31, 23, 30, 0, 0, time.UTC),
+			day:     NewDate(2025, time.January, 31),
+			iso:     "2025-01-31T23:30:00Z",
+			display: "31 Jan 2025, 23:30 WET",
+		},
+		{
+			name:    "summer is an hour ahead",
+			utc:     time.Date(2025, time.June, 30, 23, 30, 0, 0, time.UTC),
+			day:     NewDate(2025, time.July, 1),
+			iso:     "2025-07-01T00:30:00+01:00",
+			display: "01 Jul 2025, 00:30 WEST",
+		},
+		{
+			name:    "right after the clocks go forward",
+			utc:     time.Date(2025, time.March, 30, 1, 0, 0, 0, time.UTC),
+			day:     NewDate(2025, time.March, 30),
+			iso:     "2025-03-30T02:00:00+01:00",
+			display: "30 Mar 2025, 02:00 WEST",
+		},
+		{
+			name:    "right after the clocks go back",
+			utc:     time.Date(2025, time.October, 26, 1, 0, 0, 0, time.UTC),
+			day:     NewDate(2025, time.October, 26),
+			iso:     "2025-10-26T01:00:00Z",
+			display: "26 Oct 2025, 01:00 WET",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timestamp := NewTimestamp(tt.utc)
+			if got := timestamp.CalendarDate(); got != tt.day {
+				t.Errorf("CalendarDate() = %s, want %s", got, tt.day)
+			}
+			if got := timestamp.ISO(); got != tt.iso {
+				t.Errorf("ISO() = %q, want %q", got, tt.iso)
+			}
+			if got := timestamp.Display(); got != tt.display {
+				t.Errorf("Display() = %q, want %q", got, tt.display)
+			}
+		})
+	}
+}
+
+func TestDateArithmetic(t *testing.T) {
+	date := NewDate(2024, time.February, 29)
+	if got := date.AddDate(1, 0, 0); got != NewDate(2025, time.March, 1) {
+		t.Errorf("AddDate(1, 0, 0) = %s, want 2025-03-01", got)
+	}
+	// The days between two dates do not depend on the hour lost when the clocks go forward.
+	if got := NewDate(2025, time.March, 29).DaysUntil(NewDate(2025, time.April, 1)); got != 3 {
+		t.Errorf("DaysUntil() = %d, want 3", got)
+	}
+	if got := NewDate(2025, time.March, 30).Time(); !got.Equal(time.Date(2025, time.March, 30, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Time() = %s, want midnight in Lisbon", got)
+	}
+	if got := NewDate(2025, time.July, 1).Time(); !got.Equal(time.Date(2025, time.June, 30, 23, 0, 0, 0, time.UTC)) {
+		t.Errorf("Time() = %s, want midnight in Lisbon summer time", got)
+	}
+}
+
+func TestTimeJSON(t *testing.T) {
+	var output struct {
+		Executed  Timestamp  `json:"executed"`
+		Activated *Timestamp `json:"activated"`
+		Renewal   Date       `json:"renewal"`
+	}
+	output.Executed = NewTimestamp(time.Date(2025, time.July, 8, 11, 30, 0, 0, time.UTC))
+	data, err := json.Marshal(output)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := `{"executed":{"iso":"2025-07-08T12:30:00+01:00","display":"08 Jul 2025, 12:30 WEST"},"activated":null,"renewal":null}`
+	if string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	var decoded struct {
+		Executed Timestamp `json:"executed"`
+		Renewal  Date      `json:"renewal"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil || !decoded.Executed.Equal(output.Executed.Time) || !decoded.Renewal.IsZero() {
+		t.Errorf("Unmarshal() = %+v, %v, want the timestamp back and no renewal", decoded, err)
+	}
+	if err := json.Unmarshal([]byte(`{"executed":"2025-07-08T11:30:00Z"}`), &decoded); err == nil {
+		t.Errorf("Unmarshal() of a string succeeded, want only the output object")
+	}
+
+	schema := Timestamp{}.JSONSchema()
+	if len(schema.AnyOf) != 2 || schema.AnyOf[0].Type != "object" || schema.AnyOf[1].Type != "null" {
+		t.Errorf("JSONSchema() = %+v, want an object or null", schema)
+	}
+}
@@ -0,0 +1,110 @@
+package i18n
+
+import (
+	"fmt"
+
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+// cardReasonCatalogs holds the text of every card action reason, per language. The closed card reason
+// is formatted with the card status.
+var cardReasonCatalogs = map[string]map[domain.CardReason]string{
+	English: {
+		domain.CardReasonClosed:                       "The card is %s and can no longer be used.",
+		domain.CardReasonBlocked:                      "The card is blocked; unblock it in the Coverflex app if you blocked it, or contact support.",
+		domain.CardReasonNotActivated:                 "The card was issued but never activated; activate it in the Coverflex app.",
+		domain.CardReasonPlasticRequested:             "A physical card was already requested and is on its way.",
+		domain.CardReasonNoPlasticPolicyUnknown:       "There is no physical card and the company card policy is unknown; ask HR whether one can be issued.",
+		domain.CardReasonNoPlasticCanRequest:          "There is no physical card and the company allows employees to request one.",
+		domain.CardReasonNoPlasticCannotRequest:       "There is no physical card and the company does not let employees request one; ask HR.",
+		domain.CardReasonExpiredPolicyUnknown:         "The card has expired and the company card policy is unknown; ask HR about a replacement.",
+		domain.CardReasonExpiredAutomaticReplacement:  "The card has expired; the company replaces cards automatically.",
+		domain.CardReasonExpiredCanRequest:            "The card has expired; the company allows employees to request a replacement.",
+		domain.CardReasonExpiredCannotRequest:         "The card has expired and the company does not let employees request a replacement; ask HR.",
+		domain.CardReasonExpiringPolicyUnknown:        "The card is about to expire and the company card policy is unknown; ask HR about a replacement.",
+		domain.CardReasonExpiringAutomaticReplacement: "The card is about to expire; the company replaces cards automatically.",
+		domain.CardReasonExpiringCanRequest:           "The card is about to expire; the company allows employees to request a replacement.",
+		domain.CardReasonExpiringCannotRequest:        "The card is about to expire and the company does not let employees request a replacement; ask HR.",
+	},
+	Portuguese: {
+		domain.CardReasonClosed:                       "O cartão está %s e já não pode ser utilizado.",
+		domain.CardReasonBlocked:                      "O cartão está bloqueado; desbloqueie-o na app Coverflex se foi o utilizador a bloqueá-lo, ou contacte o apoio.",
+		domain.CardReasonNotActivated:                 "O cartão foi emitido mas nunca foi ativado; ative-o na app Coverflex.",
+		domain.CardReasonPlasticRequested:             "Já foi pedido um cartão físico, que está a caminho.",
+		domain.CardReasonNoPlasticPolicyUnknown:       "Não existe cartão físico e a política de cartões da empresa é desconhecida; pergunte aos RH se pode ser emitido um.",
+		domain.CardReasonNoPlasticCanRequest:          "Não existe cartão físico e a empresa permite que os colaboradores peçam um.",
+		domain.CardReasonNoPlasticCannotRequest:       "Não existe cartão físico e a empresa não permite que os colaboradores peçam um; pergunte aos RH.",
+		domain.CardReasonExpiredPolicyUnknown:         "O cartão expirou e a política de cartões da empresa é desconhecida; pergunte aos RH por uma substituição.",
+		domain.CardReasonExpiredAutomaticReplacement:  "O cartão expirou; a empresa substitui os cartões automaticamente.",
+		domain.CardReasonExpiredCanRequest:            "O cartão expirou; a empresa permite que os colaboradores peçam uma substituição.",
+		domain.CardReasonExpiredCannotRequest:         "O cartão expirou e a empresa não permite que os colaboradores peçam uma substituição; pergunte aos RH.",
+		domain.CardReasonExpiringPolicyUnknown:        "O cartão está prestes a expirar e a política de cartões da empresa é desconhecida; pergunte aos RH por uma substituição.",
+		domain.CardReasonExpiringAutomaticReplacement: "O cartão está prestes a expirar; a empresa substitui os cartões automaticamente.",
+		domain.CardReasonExpiringCanRequest:           "O cartão está prestes a expirar; a empresa permite que os colaboradores peçam uma substituição.",
+		domain.CardReasonExpiringCannotRequest:        "O cartão está prestes a expirar e a empresa não permite que os colaboradores peçam uma substituição; pergunte aos RH.",
+	},
+	Spanish: {
+		domain.CardReasonClosed:                       "La tarjeta está %s y ya no se puede utilizar.",
+		domain.CardReasonBlocked:                      "La tarjeta está bloqueada; desbloquéala en la app de Coverflex si la bloqueaste tú, o contacta con soporte.",
+		domain.CardReasonNotActivated:                 "La tarjeta se emitió pero nunca se activó; actívala en la app de Coverflex.",
+		domain.CardReasonPlasticRequested:             "Ya se solicitó una tarjeta física y está en camino.",
+		domain.CardReasonNoPlasticPolicyUnknown:       "No hay tarjeta física y se desconoce la política de tarjetas de la empresa; pregunta a RR. HH. si se puede emitir una.",
+		domain.CardReasonNoPlasticCanRequest:          "No hay tarjeta física y la empresa permite a los empleados solicitar una.",
+		domain.CardReasonNoPlasticCannotRequest:       "No hay tarjeta física y la empresa no permite a los empleados solicitar una; pregunta a RR. HH.",
+		domain.CardReasonExpiredPolicyUnknown:         "La tarjeta ha caducado y se desconoce la política de tarjetas de la empresa; pregunta a RR. HH. por una sustitución.",
+		domain.CardReasonExpiredAutomaticReplacement:  "La tarjeta ha caducado; la empresa sustituye las tarjetas automáticamente.",
+		domain.CardReasonExpiredCanRequest:            "La tarjeta ha caducado; la empresa permite a los empleados solicitar una sustitución.",
+		domain.CardReasonExpiredCannotRequest:         "La tarjeta ha caducado y la empresa no permite a los empleados solicitar una sustitución; pregunta a RR. HH.",
+		domain.CardReasonExpiringPolicyUnknown:        "La tarjeta está a punto de caducar y se desconoce la política de tarjetas de la empresa; pregunta a RR. HH. por una sustitución.",
+		domain.CardReasonExpiringAutomaticReplacement: "La tarjeta está a punto de caducar; la empresa sustituye las tarjetas automáticamente.",
+		domain.CardReasonExpiringCanRequest:           "La tarjeta está a punto de caducar; la empresa permite a los empleados solicitar una sustitución.",
+		domain.CardReasonExpiringCannotRequest:        "La tarjeta está a punto de caducar y la empresa no permite a los empleados solicitar una sustitución; pregunta a RR. HH.",
+	},
+}
+
+// CardReason renders the reason a card action is suggested in the given locale, falling back to the
+// DefaultLanguage text and to the reason code itself.
+func CardReason(locale string, card domain.Card, reason domain.CardReason) string {
+	text, ok := cardReasonCatalogs[Normalize(locale)][reason]
+	if !ok {
+		text, ok = cardReasonCatalogs[DefaultLanguage][reason]
+	}
+	if !ok {
+		return string(reason)
+	}
+	if reason == domain.CardReasonClosed {
+		return fmt.Sprintf(text, card.Status)
+	}
+	return text
+}
+
+// eligibilityReasonCatalogs holds why each benefit category applies to a family member, per language.
+var eligibilityReasonCatalogs = map[string]map[domain.CategorySlug]string{
+	English: {
+		domain.CategoryNursery:   "Childcare vouchers cover children under 7 years old.",
+		domain.CategoryEducation: "Education vouchers cover dependant children aged 7 to 25.",
+		domain.CategoryHealth:    "Health expenses cover the employee household.",
+		domain.CategoryInsurance: "Insurance can be extended to the employee household.",
+	},
+	Portuguese: {
+		domain.CategoryNursery:   "O vale infância cobre crianças com menos de 7 anos.",
+		domain.CategoryEducation: "O vale educação cobre dependentes entre os 7 e os 25 anos.",
+		domain.CategoryHealth:    "As despesas de saúde cobrem o agregado familiar do colaborador.",
+		domain.CategoryInsurance: "O seguro pode ser alargado ao agregado familiar do colaborador.",
+	},
+	Spanish: {
+		domain.CategoryNursery:   "Los vales de guardería cubren a los niños menores de 7 años.",
+		domain.CategoryEducation: "Los vales de educación cubren a los hijos dependientes de 7 a 25 años.",
+		domain.CategoryHealth:    "Los gastos de salud cubren a la unidad familiar del empleado.",
+		domain.CategoryInsurance: "El seguro se puede ampliar a la unidad familiar del empleado.",
+	},
+}
+
+// EligibilityReason renders why the benefit category applies to a family member in the given locale,
+// falling back to the DefaultLanguage text and to an empty reason.
+func EligibilityReason(locale string, category domain.CategorySlug) string {
+	if text, ok := eligibilityReasonCatalogs[Normalize(locale)][category]; ok {
+		return text
+	}
+	return eligibilityReasonCatalogs[DefaultLanguage][category]
+}
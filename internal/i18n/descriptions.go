@@ -0,0 +1,211 @@
+package i18n
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+// descriptionCatalogs maps each operation description tag to its templates, per language.
+// Templates reference the description params as {key}. They are tried in order, and the
+// first one whose params are all present is used, so the most specific goes first.
+var descriptionCatalogs = map[string]map[string][]string{
+	English: {
+		"card_payment":          {"Card payment at {merchant_name}", "Card payment"},
+		"card_payment_reversal": {"Reversal of the card payment at {merchant_name}", "Reversal of a card payment"},
+		"card_refund":           {"Refund from {merchant_name}", "Card refund"},
+		"card_withdrawal":       {"Cash withdrawal at {merchant_name}", "Cash withdrawal"},
+		"refund":                {"Refund from {merchant_name}", "Refund"},
+		"top_up":                {"Top-up of {benefit_name}", "Top-up of {product_name}", "Balance top-up"},
+		"benefit_attribution":   {"{benefit_name} allowance credited", "Allowance credited"},
+		"meal_allowance":        {"Meal allowance credited"},
+		"rollover":              {"Unused {benefit_name} balance rolled over to {target_benefit_name}", "Unused {benefit_name} balance rolled over", "Unused balance rolled over to another benefit"},
+		"rollover_top_up":       {"{benefit_name} topped up with the balance rolled over from {source_benefit_name}", "{benefit_name} topped up with rolled over balance", "Top-up with balance rolled over from another benefit"},
+		"reimbursement":         {"Reimbursement of the {product_name} receipt", "Receipt reimbursement"},
+		"expiration":            {"Expired {benefit_name} balance", "Expired balance"},
+	},
+	Portuguese: {
+		"card_payment":          {"Pagamento com cartão em {merchant_name}", "Pagamento com cartão"},
+		"card_payment_reversal": {"Anulação do pagamento com cartão em {merchant_name}", "Anulação de pagamento com cartão"},
+		"card_refund":           {"Reembolso de {merchant_name}", "Reembolso do cartão"},
+		"card_withdrawal":       {"Levantamento em {merchant_name}", "Levantamento"},
+		"refund":                {"Reembolso de {merchant_name}", "Reembolso"},
+		"top_up":                {"Carregamento de {benefit_name}", "Carregamento de {product_name}", "Carregamento de saldo"},
+		"benefit_attribution":   {"Atribuição de {benefit_name}", "Atribuição de saldo"},
+		"meal_allowance":        {"Atribuição do subsídio de refeição"},
+		"rollover":              {"Saldo não utilizado de {benefit_name} transferido para {target_benefit_name}", "Saldo não utilizado de {benefit_name} transferido", "Saldo não utilizado transferido para outro benefício"},
+		"rollover_top_up":       {"{benefit_name} carregado com o saldo transferido de {source_benefit_name}", "{benefit_name} carregado com saldo transferido", "Carregamento com saldo transferido de outro benefício"},
+		"reimbursement":         {"Reembolso da fatura de {product_name}", "Reembolso de fatura"},
+		"expiration":            {"Saldo de {benefit_name} expirado", "Saldo expirado"},
+	},
+	Spanish: {
+		"card_payment":          {"Pago con tarjeta en {merchant_name}", "Pago con tarjeta"},
+		"card_payment_reversal": {"Anulación del pago con tarjeta en {merchant_name}", "Anulación de un pago con tarjeta"},
+		"card_refund":           {"Reembolso de {merchant_name}", "Reembolso de la tarjeta"},
+		"card_withdrawal":       {"Retirada de efectivo en {merchant_name}", "Retirada de efectivo"},
+		"refund":                {"Reembolso de {merchant_name}", "Reembolso"},
+		"top_up":                {"Recarga de {benefit_name}", "Recarga de {product_name}", "Recarga de saldo"},
+		"benefit_attribution":   {"Asignación de {benefit_name}", "Asignación de saldo"},
+		"meal_allowance":        {"Asignación de la ayuda para comida"},
+		"rollover":              {"Saldo no utilizado de {benefit_name} traspasado a {target_benefit_name}", "Saldo no utilizado de {benefit_name} traspasado", "Saldo no utilizado traspasado a otro beneficio"},
+		"rollover_top_up":       {"{benefit_name} recargado con el saldo traspasado de {source_benefit_name}", "{benefit_name} recargado con saldo traspasado", "Recarga con saldo traspasado de otro beneficio"},
+		"reimbursement":         {"Reembolso de la factura de {product_name}", "Reembolso de factura"},
+		"expiration":            {"Saldo de {benefit_name} caducado", "Saldo caducado"},
+	},
+}
+
+var placeholderPattern = regexp.MustCompile(`\{([a-z0-9_]+)\}`)
+
+// UnmappedTag describes an operation description tag that has no template in the catalogs.
+type UnmappedTag struct {
+	Tag string `json:"tag"`
+	// Count is the number of descriptions rendered with the tag.
+	Count int `json:"count"`
+	// ParamKeys are the description param keys seen with the tag.
+	ParamKeys []string `json:"param_keys"`
+}
+
+// DescriptionRenderer turns operation description tags and params into human-readable sentences.
+// Tags without a template fall back to a humanized version of the tag followed by its params,
+// and are recorded so they can be reported and added to the catalogs.
+type DescriptionRenderer struct {
+	mu       sync.Mutex
+	unmapped map[string]*unmappedTag
+}
+
+type unmappedTag struct {
+	count     int
+	paramKeys map[string]struct{}
+}
+
+// NewDescriptionRenderer creates a renderer with the built-in pt, es and en catalogs.
+func NewDescriptionRenderer() *DescriptionRenderer {
+	return &DescriptionRenderer{unmapped: make(map[string]*unmappedTag)}
+}
+
+// Render returns the description of an operation in the given locale.
+func (r *DescriptionRenderer) Render(locale, tag string, params map[string]any) string {
+	language := Normalize(locale)
+	values := make(map[string]string, len(params))
+	for key, value := range params {
+		if text := formatParam(value, language); text != "" {
+			values[key] = text
+		}
+	}
+
+	templates, ok := descriptionCatalogs[language][tag]
+	if !ok {
+		templates, ok = descriptionCatalogs[DefaultLanguage][tag]
+	}
+	if !ok {
+		r.recordUnmapped(tag, params)
+		return fallbackDescription(tag, values)
+	}
+
+	for _, template := range templates {
+		if text, ok := fillTemplate(template, values); ok {
+			return text
+		}
+	}
+	return fallbackDescription(tag, values)
+}
+
+// Unmapped returns the tags rendered so far that have no template, sorted by tag.
+func (r *DescriptionRenderer) Unmapped() []UnmappedTag {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tags := make([]UnmappedTag, 0, len(r.unmapped))
+	for _, tag := range slices.Sorted(maps.Keys(r.unmapped)) {
+		unmapped := r.unmapped[tag]
+		tags = append(tags, UnmappedTag{
+			Tag:       tag,
+			Count:     unmapped.count,
+			ParamKeys: slices.Sorted(maps.Keys(unmapped.paramKeys)),
+		})
+	}
+	return tags
+}
+
+func (r *DescriptionRenderer) recordUnmapped(tag string, params map[string]any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	unmapped, ok := r.unmapped[tag]
+	if !ok {
+		unmapped = &unmappedTag{paramKeys: make(map[string]struct{})}
+		r.unmapped[tag] = unmapped
+		slog.Warn("Unmapped operation description tag", "tag", tag, "param_keys", slices.Sorted(maps.Keys(params)))
+	}
+	unmapped.count++
+	for key := range params {
+		unmapped.paramKeys[key] = struct{}{}
+	}
+}
+
+// fillTemplate replaces the placeholders of the template. It fails if any of them has no value.
+func fillTemplate(template string, values map[string]string) (string, bool) {
+	complete := true
+	text := placeholderPattern.ReplaceAllStringFunc(template, func(placeholder string) string {
+		value, ok := values[placeholder[1:len(placeholder)-1]]
+		if !ok {
+			complete = false
+		}
+		return value
+	})
+	return text, complete
+}
+
+// fallbackDescription humanizes an unknown tag, e.g. "gift_card_purchase" becomes
+// "Gift card purchase", and appends the params as "key: value" pairs.
+func fallbackDescription(tag string, values map[string]string) string {
+	text := strings.TrimSpace(strings.NewReplacer("_", " ", ".", " ", "-", " ").Replace(tag))
+	if first, size := utf8.DecodeRuneInString(text); size > 0 {
+		text = string(unicode.ToUpper(first)) + text[size:]
+	}
+
+	if len(values) == 0 {
+		return text
+	}
+	pairs := make([]string, 0, len(values))
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		pairs = append(pairs, strings.ReplaceAll(key, "_", " ")+": "+values[key])
+	}
+	if text == "" {
+		return strings.Join(pairs, ", ")
+	}
+	return text + " (" + strings.Join(pairs, ", ") + ")"
+}
+
+// formatParam renders a description param value. Monetary values are formatted in the given language.
+func formatParam(value any, language string) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case map[string]any:
+		amount, hasAmount := v["amount"].(float64)
+		currency, hasCurrency := v["currency"].(string)
+		if hasAmount && hasCurrency {
+			return domain.NewMoney(int64(amount), currency).Format(language)
+		}
+		if name, ok := v["name"].(string); ok {
+			return name
+		}
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,110 @@
+package i18n
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"pt":               Portuguese,
+		"pt-PT":            Portuguese,
+		"es_ES":            Spanish,
+		"EN-gb,en;q=0.9":   English,
+		"pt-BR;q=0.8, en":  Portuguese,
+		"fr-FR":            DefaultLanguage,
+		"":                 DefaultLanguage,
+		" es ":             Spanish,
+		"de,pt;q=0.9,en":   DefaultLanguage,
+		"es-419,es;q=0.9 ": Spanish,
+	}
+	for locale, want := range tests {
+		if got := Normalize(locale); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", locale, got, want)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale string
+		tag    string
+		params map[string]any
+		want   string
+	}{
+		{"most specific template", "pt-PT", "card_payment", map[string]any{"merchant_name": " Pingo Doce "}, "Pagamento com cartão em Pingo Doce"},
+		{"template without the missing param", "es", "card_payment", map[string]any{}, "Pago con tarjeta"},
+		{"empty params are missing", "en", "card_payment", map[string]any{"merchant_name": nil}, "Card payment"},
+		{"second template", "en", "top_up", map[string]any{"product_name": "Meal card"}, "Top-up of Meal card"},
+		{"two params", "es", "rollover", map[string]any{"benefit_name": "Comida", "target_benefit_name": "Salud"},
+			"Saldo no utilizado de Comida traspasado a Salud"},
+		{"unsupported language", "fr", "refund", map[string]any{"merchant_name": "Worten"}, "Refund from Worten"},
+		{"named object param", "pt", "benefit_attribution", map[string]any{"benefit_name": map[string]any{"name": "Saúde"}}, "Atribuição de Saúde"},
+		{"unknown tag with money", "pt", "gift_card_purchase", map[string]any{"amount": map[string]any{"amount": float64(123456), "currency": "EUR"}, "count": float64(2)},
+			"Gift card purchase (amount: 1 234,56 €, count: 2)"},
+		{"unknown tag in English", "en", "cashback.credit", map[string]any{"amount": map[string]any{"amount": float64(-50), "currency": "EUR"}},
+			"Cashback credit (amount: -€0.50)"},
+		{"unknown tag without params", "es", "gift-card", nil, "Gift card"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewDescriptionRenderer().Render(tt.locale, tt.tag, tt.params); got != tt.want {
+				t.Errorf("Render(%q, %q) = %q, want %q", tt.locale, tt.tag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderRecordsUnmappedTags(t *testing.T) {
+	renderer := NewDescriptionRenderer()
+	renderer.Render("pt", "card_payment", map[string]any{"merchant_name": "Continente"})
+	renderer.Render("pt", "voucher", map[string]any{"code": "A1"})
+	renderer.Render("en", "voucher", map[string]any{"partner": "Lidl"})
+	renderer.Render("es", "cashback", nil)
+
+	want := []UnmappedTag{
+		{Tag: "cashback", Count: 1, ParamKeys: []string{}},
+		{Tag: "voucher", Count: 2, ParamKeys: []string{"code", "partner"}},
+	}
+	got := renderer.Unmapped()
+	if !slices.EqualFunc(got, want, func(a, b UnmappedTag) bool {
+		return a.Tag == b.Tag && a.Count == b.Count && slices.Equal(a.ParamKeys, b.ParamKeys)
+	}) {
+		t.Errorf("Unmapped() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCatalogsCoverTheSameKeys(t *testing.T) {
+	tags := slices.Sorted(maps.Keys(descriptionCatalogs[DefaultLanguage]))
+	messages := slices.Sorted(maps.Keys(catalogs[DefaultLanguage]))
+	for _, language := range []string{Portuguese, Spanish} {
+		if got := slices.Sorted(maps.Keys(descriptionCatalogs[language])); !slices.Equal(got, tags) {
+			t.Errorf("%s description tags = %q, want %q", language, got, tags)
+		}
+		for _, tag := range tags {
+			if got, want := len(descriptionCatalogs[language][tag]), len(descriptionCatalogs[DefaultLanguage][tag]); got != want {
+				t.Errorf("%s has %d templates for %s, want %d like %s", language, got, tag, want, DefaultLanguage)
+			}
+		}
+		if got := slices.Sorted(maps.Keys(catalogs[language])); !slices.Equal(got, messages) {
+			t.Errorf("%s messages = %q, want %q", language, got, messages)
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	if got := T("pt-PT", ErrGetCards); got != "erro ao obter os cartões" {
+		t.Errorf("T(pt-PT) = %q, want the Portuguese text", got)
+	}
+	if got := T("fr", ErrGetCards); got != "error getting cards" {
+		t.Errorf("T(fr) = %q, want the English text", got)
+	}
+	if got := T("es", Message("missing.key")); got != "missing.key" {
+		t.Errorf("T() of a missing message = %q, want its key", got)
+	}
+	if got := T("en", UnknownRenewal, "timeout"); got != "the compensation could not be fetched, so the benefit year is assumed to be the calendar year: timeout" {
+		t.Errorf("T() with arguments = %q", got)
+	}
+}
@@ -0,0 +1,52 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Supported languages.
+const (
+	Portuguese = "pt"
+	Spanish    = "es"
+	English    = "en"
+)
+
+// DefaultLanguage is used when the requested language is not supported.
+const DefaultLanguage = English
+
+// Message identifies a text produced by the server itself.
+type Message string
+
+// catalogs holds the translation of every message, per language.
+var catalogs = map[string]map[Message]string{}
+
+// Normalize reduces a locale such as "pt-PT", "es_ES" or "en-GB,en;q=0.9" to a supported base language.
+// It returns DefaultLanguage when the language is not supported.
+func Normalize(locale string) string {
+	locale, _, _ = strings.Cut(locale, ",")
+	locale, _, _ = strings.Cut(locale, ";")
+	base, _, _ := strings.Cut(strings.TrimSpace(locale), "-")
+	base, _, _ = strings.Cut(base, "_")
+	base = strings.ToLower(base)
+	if _, ok := catalogs[base]; ok {
+		return base
+	}
+	return DefaultLanguage
+}
+
+// T returns the message translated into the given locale, formatted with the optional arguments.
+// It falls back to the DefaultLanguage translation, and to the message key itself, when no translation exists.
+func T(locale string, msg Message, args ...any) string {
+	text, ok := catalogs[Normalize(locale)][msg]
+	if !ok {
+		text, ok = catalogs[DefaultLanguage][msg]
+	}
+	if !ok {
+		text = string(msg)
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(text, args...)
+	}
+	return text
+}
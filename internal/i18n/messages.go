@@ -0,0 +1,89 @@
+package i18n
+
+// Messages produced by the MCP tools.
+const (
+	ErrGetBenefits     Message = "error.get_benefits"
+	ErrGetCards        Message = "error.get_cards"
+	ErrGetCompany      Message = "error.get_company"
+	ErrGetCompensation Message = "error.get_compensation"
+	ErrGetFamily       Message = "error.get_family"
+	ErrGetOperation    Message = "error.get_operation"
+	ErrGetOperations   Message = "error.get_operations"
+	ErrInvalidFilter   Message = "error.invalid_filter"
+	ErrSyncOperations  Message = "error.sync_operations"
+	ErrInvalidQuery    Message = "error.invalid_query"
+	ErrRequestOTP      Message = "error.request_otp"
+	ErrSubmitOTP       Message = "error.submit_otp"
+	CredentialsNotSet  Message = "login.credentials_not_set"
+	AlreadyLoggedIn    Message = "login.already_logged_in"
+	OTPRequired        Message = "login.otp_required"
+	OTPRequested       Message = "login.otp_requested"
+	OTPSubmitted       Message = "login.otp_submitted"
+	// UnknownRenewal warns that the compensation, and so the renewal date, could not be fetched.
+	UnknownRenewal Message = "warning.unknown_renewal"
+)
+
+func init() {
+	catalogs[English] = map[Message]string{
+		ErrGetBenefits:     "error getting benefits",
+		ErrGetCards:        "error getting cards",
+		ErrGetCompany:      "error getting company",
+		ErrGetCompensation: "error getting compensation",
+		ErrGetFamily:       "error getting family members",
+		ErrGetOperation:    "error getting operation",
+		ErrGetOperations:   "error getting operations",
+		ErrInvalidFilter:   "invalid filter",
+		ErrSyncOperations:  "error syncing operations",
+		ErrInvalidQuery:    "invalid query",
+		ErrRequestOTP:      "error requesting OTP",
+		ErrSubmitOTP:       "error submitting OTP",
+		CredentialsNotSet:  "COVERFLEX_USERNAME and COVERFLEX_PASSWORD env vars must be set",
+		AlreadyLoggedIn:    "already logged in",
+		OTPRequired:        "otp is required",
+		OTPRequested:       "OTP requested successfully. Please let the user provide the OTP and configure it using the 'trust_device_via_otp' tool.",
+		OTPSubmitted:       "OTP submitted successfully. Device trusted, refresh the MCP servers to see the available tools.",
+		UnknownRenewal:     "the compensation could not be fetched, so the benefit year is assumed to be the calendar year: %v",
+	}
+
+	catalogs[Portuguese] = map[Message]string{
+		ErrGetBenefits:     "erro ao obter os benefícios",
+		ErrGetCards:        "erro ao obter os cartões",
+		ErrGetCompany:      "erro ao obter a empresa",
+		ErrGetCompensation: "erro ao obter a compensação",
+		ErrGetFamily:       "erro ao obter os membros do agregado familiar",
+		ErrGetOperation:    "erro ao obter o movimento",
+		ErrGetOperations:   "erro ao obter os movimentos",
+		ErrInvalidFilter:   "filtro inválido",
+		ErrSyncOperations:  "erro ao sincronizar os movimentos",
+		ErrInvalidQuery:    "consulta inválida",
+		ErrRequestOTP:      "erro ao pedir o código OTP",
+		ErrSubmitOTP:       "erro ao submeter o código OTP",
+		CredentialsNotSet:  "as variáveis de ambiente COVERFLEX_USERNAME e COVERFLEX_PASSWORD têm de estar definidas",
+		AlreadyLoggedIn:    "sessão já iniciada",
+		OTPRequired:        "o código otp é obrigatório",
+		OTPRequested:       "Código OTP pedido com sucesso. Peça ao utilizador o código OTP e configure-o com a ferramenta 'trust_device_via_otp'.",
+		OTPSubmitted:       "Código OTP submetido com sucesso. Dispositivo confiável, atualize os servidores MCP para ver as ferramentas disponíveis.",
+		UnknownRenewal:     "não foi possível obter a compensação, por isso assume-se que o ano do benefício é o ano civil: %v",
+	}
+
+	catalogs[Spanish] = map[Message]string{
+		ErrGetBenefits:     "error al obtener los beneficios",
+		ErrGetCards:        "error al obtener las tarjetas",
+		ErrGetCompany:      "error al obtener la empresa",
+		ErrGetCompensation: "error al obtener la compensación",
+		ErrGetFamily:       "error al obtener los miembros de la familia",
+		ErrGetOperation:    "error al obtener el movimiento",
+		ErrGetOperations:   "error al obtener los movimientos",
+		ErrInvalidFilter:   "filtro no válido",
+		ErrSyncOperations:  "error al sincronizar los movimientos",
+		ErrInvalidQuery:    "consulta no válida",
+		ErrRequestOTP:      "error al solicitar el código OTP",
+		ErrSubmitOTP:       "error al enviar el código OTP",
+		CredentialsNotSet:  "las variables de entorno COVERFLEX_USERNAME y COVERFLEX_PASSWORD deben estar definidas",
+		AlreadyLoggedIn:    "ya se ha iniciado sesión",
+		OTPRequired:        "el código otp es obligatorio",
+		OTPRequested:       "Código OTP solicitado correctamente. Pide al usuario el código OTP y configúralo con la herramienta 'trust_device_via_otp'.",
+		OTPSubmitted:       "Código OTP enviado correctamente. Dispositivo de confianza, actualiza los servidores MCP para ver las herramientas disponibles.",
+		UnknownRenewal:     "no se pudo obtener la compensación, así que se asume que el año del beneficio es el año natural: %v",
+	}
+}
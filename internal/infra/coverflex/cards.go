@@ -1,41 +1,51 @@
 package coverflex
 
-import "log/slog"
+import (
+	"context"
+	"log/slog"
+	"net/http"
 
-// Card represents a single employee card.
-type Card struct {
-	ID                 string  `json:"id"`
-	ActivatedAt        *string `json:"activated_at"`
-	ExpirationDate     string  `json:"expiration_date"`
-	Format             string  `json:"format"`
-	HolderCompanyName  string  `json:"holder_company_name"`
-	HolderName         string  `json:"holder_name"`
-	IsExpiring         bool    `json:"is_expiring"`
-	IsPlasticRequested bool    `json:"is_plastic_requested"`
-	Network            string  `json:"network"`
-	OwnerID            string  `json:"owner_id"`
-	PANLastDigits      string  `json:"pan_last_digits"`
-	ProviderID         string  `json:"provider_id"`
-	Status             string  `json:"status"`
-	Version            string  `json:"version"`
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+// cardDTO represents a single employee card.
+type cardDTO struct {
+	ID                 string        `json:"id"`
+	ActivatedAt        *timestampDTO `json:"activated_at"`
+	ExpirationDate     dateDTO       `json:"expiration_date"`
+	Format             string        `json:"format"`
+	HolderCompanyName  string        `json:"holder_company_name"`
+	HolderName         string        `json:"holder_name"`
+	IsExpiring         bool          `json:"is_expiring"`
+	IsPlasticRequested bool          `json:"is_plastic_requested"`
+	Network            string        `json:"network"`
+	OwnerID            string        `json:"owner_id"`
+	PANLastDigits      string        `json:"pan_last_digits"`
+	ProviderID         string        `json:"provider_id"`
+	Status             string        `json:"status"`
+	Version            string        `json:"version"`
 }
 
-// CardsResponse is the top-level structure for the cards API response.
-type CardsResponse struct {
-	Cards []Card `json:"cards"`
+// cardsResponse is the top-level structure for the cards API response.
+type cardsResponse struct {
+	Cards []cardDTO `json:"cards"`
 }
 
 // GetCards fetches the employee's cards from the Coverflex API.
 // It automatically handles token refresh if the current token is expired.
 // It returns a slice of Card structs containing detailed information about each card
 // or an error if the request fails or the response cannot be decoded.
-func (c *Client) GetCards() ([]Card, error) {
+func (c *Client) GetCards(ctx context.Context) ([]domain.Card, error) {
 	slog.Info("Fetching employee cards information...")
 
-	var response CardsResponse
-	if err := c.get("https://menhir-api.coverflex.com/api/employee/cards", &response); err != nil {
+	response, err := doRequest[cardsResponse](ctx, c, apiRequest{
+		method: http.MethodGet,
+		url:    cardsURL,
+		auth:   authSession,
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return response.Cards, nil
+	return mapSlice(response.Cards, toDomainCard), nil
 }
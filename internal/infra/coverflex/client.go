@@ -1,35 +1,83 @@
 package coverflex
 
 import (
-	"encoding/json"
-	"fmt"
-	"io"
-	"log/slog"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/tembleking/coverflex-mcp/internal/domain"
 )
 
 // API endpoints
 const (
-	sessionURL    = "https://menhir-api.coverflex.com/api/employee/sessions"
-	trustURL      = "https://menhir-api.coverflex.com/api/employee/sessions/trust-user-agent"
-	refreshURL    = "https://menhir-api.coverflex.com/api/employee/sessions/renew"
-	operationsURL = "https://menhir-api.coverflex.com/api/employee/operations"
+	sessionURL      = "https://menhir-api.coverflex.com/api/employee/sessions"
+	trustURL        = "https://menhir-api.coverflex.com/api/employee/sessions/trust-user-agent"
+	refreshURL      = "https://menhir-api.coverflex.com/api/employee/sessions/renew"
+	operationsURL   = "https://menhir-api.coverflex.com/api/employee/operations"
+	benefitsURL     = "https://menhir-api.coverflex.com/api/employee/benefits"
+	cardsURL        = "https://menhir-api.coverflex.com/api/employee/cards"
+	companyURL      = "https://menhir-api.coverflex.com/api/employee/company"
+	compensationURL = "https://menhir-api.coverflex.com/api/employee/compensation"
+	familyURL       = "https://menhir-api.coverflex.com/api/employee/family"
 )
 
 // Client is the Coverflex API client.
 type Client struct {
 	httpClient *http.Client
 	tokenRepo  domain.TokenRepository
+	// refreshMu serializes token refreshes triggered by concurrent requests.
+	refreshMu sync.Mutex
+	// locale is the configured Accept-Language. When empty, defaultLocale is discovered from the company market.
+	locale string
+	// localeMu guards defaultLocale and the state of its discovery, but is not held while fetching it.
+	localeMu      sync.Mutex
+	defaultLocale string
+	// localeFetch is closed when the discovery in flight ends, and nil when there is none.
+	localeFetch   chan struct{}
+	localeRetryAt time.Time
+	// drift records the schema drift of the API responses when strict decoding is enabled.
+	drift *driftDetector
 }
 
+// The client implements every domain port.
+var (
+	_ domain.Authenticator          = (*Client)(nil)
+	_ domain.BenefitsReader         = (*Client)(nil)
+	_ domain.CardsReader            = (*Client)(nil)
+	_ domain.CardStatusReader       = (*Client)(nil)
+	_ domain.CompanyReader          = (*Client)(nil)
+	_ domain.CompensationReader     = (*Client)(nil)
+	_ domain.FamilyReader           = (*Client)(nil)
+	_ domain.FamilyInsightsReader   = (*Client)(nil)
+	_ domain.OperationReader        = (*Client)(nil)
+	_ domain.OperationsCursorReader = (*Client)(nil)
+	_ domain.OperationsReader       = (*Client)(nil)
+	_ domain.OverviewReader         = (*Client)(nil)
+)
+
 // NewClient creates a new Coverflex API client.
-func NewClient(tokenRepo domain.TokenRepository) *Client {
-	return &Client{
-		httpClient: &http.Client{},
+// The HTTP transport can be customized through functional options (timeouts, proxy, root CAs, User-Agent).
+func NewClient(tokenRepo domain.TokenRepository, opts ...ClientOption) *Client {
+	cfg := &clientConfig{
+		timeout:               DefaultTimeout,
+		dialTimeout:           DefaultDialTimeout,
+		tlsHandshakeTimeout:   DefaultTLSHandshakeTimeout,
+		responseHeaderTimeout: DefaultResponseHeaderTimeout,
+		userAgent:             DefaultUserAgent(),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	client := &Client{
+		httpClient: newHTTPClient(cfg),
 		tokenRepo:  tokenRepo,
+		locale:     cfg.locale,
+	}
+	if cfg.strictDecoding {
+		client.drift = newDriftDetector()
 	}
+	return client
 }
 
 // IsLoggedIn checks if the user is logged in by verifying the existence of tokens.
@@ -38,67 +86,6 @@ func (c *Client) IsLoggedIn() bool {
 	return err == nil
 }
 
-// get handles the common logic for making a GET request to the Coverflex API.
-// It manages token retrieval, authorization headers, request execution, response decoding,
-// and automatic token refresh on a 401 Unauthorized status.
-func (c *Client) get(url string, target interface{}) error {
-	tokens, err := c.tokenRepo.GetTokens()
-	if err != nil {
-		return fmt.Errorf("not logged in: %w", err)
-	}
-
-	// Initial request
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return fmt.Errorf("error creating request: %w", err)
-	}
-	req.Header.Set("accept", "application/json, text/plain, */*")
-	req.Header.Set("authorization", "Bearer "+tokens.AccessToken)
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("error performing request: %w", err)
-	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			slog.Warn("failed to close response body", "error", err)
-		}
-	}()
-
-	// Handle token refresh and retry
-	if resp.StatusCode == http.StatusUnauthorized {
-		slog.Info("Token expired. Refreshing...")
-		newAuthToken, _ := c.RefreshTokens(tokens.RefreshToken)
-		if newAuthToken == "" {
-			_ = c.tokenRepo.DeleteTokens()
-			return fmt.Errorf("token refresh failed")
-		}
-
-		slog.Info("Retrying request with new token...")
-		req.Header.Set("authorization", "Bearer "+newAuthToken)
-		resp, err = c.httpClient.Do(req)
-		if err != nil {
-			return fmt.Errorf("error performing retry request: %w", err)
-		}
-		defer func() {
-			if err := resp.Body.Close(); err != nil {
-				slog.Warn("failed to close retry response body", "error", err)
-			}
-		}()
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("unexpected status code: %d\nResponse: %s", resp.StatusCode, string(bodyBytes))
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
-		return fmt.Errorf("error decoding response: %w", err)
-	}
-
-	return nil
-}
-
 // Structs for JSON payloads
 type sessionRequest struct {
 	Email    string `json:"email"`
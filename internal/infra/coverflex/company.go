@@ -1,9 +1,15 @@
 package coverflex
 
-import "log/slog"
+import (
+	"context"
+	"log/slog"
+	"net/http"
 
-// Address represents a company address.
-type Address struct {
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+// addressDTO represents a company address.
+type addressDTO struct {
 	AddressLine1 string  `json:"address_line_1"`
 	AddressLine2 *string `json:"address_line_2"`
 	City         string  `json:"city"`
@@ -13,14 +19,14 @@ type Address struct {
 	Zipcode      string  `json:"zipcode"`
 }
 
-// Market represents the market information for a company.
-type Market struct {
+// marketDTO represents the market information for a company.
+type marketDTO struct {
 	Languages []string `json:"languages"`
 	Slug      string   `json:"slug"`
 }
 
-// Settings represents the company settings.
-type Settings struct {
+// settingsDTO represents the company settings.
+type settingsDTO struct {
 	CardRequestEmployeePermission  string `json:"card_request_employee_permission"`
 	CardRequestFormat              string `json:"card_request_format"`
 	CardRequestStrategy            string `json:"card_request_strategy"`
@@ -31,47 +37,52 @@ type Settings struct {
 	SavingsEmployeeEnabled         bool   `json:"savings_employee_enabled"`
 }
 
-// TaxID represents the tax ID of a company.
-type TaxID struct {
+// taxIDDTO represents the tax ID of a company.
+type taxIDDTO struct {
 	Type  string `json:"type"`
 	Value string `json:"value"`
 }
 
-// Company represents the company information.
-type Company struct {
-	ID              string    `json:"id,omitempty"`
-	Addresses       []Address `json:"addresses,omitempty"`
-	CardDisplayName string    `json:"card_display_name,omitempty"`
-	LegalName       string    `json:"legal_name,omitempty"`
-	LogoURI         *string   `json:"logo_uri,omitempty"`
-	Market          Market    `json:"market,omitempty"`
-	Name            string    `json:"name,omitempty"`
-	Settings        Settings  `json:"settings,omitempty"`
-	TaxID           TaxID     `json:"tax_id,omitempty"`
+// companyDTO represents the company information.
+type companyDTO struct {
+	ID              string       `json:"id,omitempty"`
+	Addresses       []addressDTO `json:"addresses,omitempty"`
+	CardDisplayName string       `json:"card_display_name,omitempty"`
+	LegalName       string       `json:"legal_name,omitempty"`
+	LogoURI         *string      `json:"logo_uri,omitempty"`
+	Market          marketDTO    `json:"market,omitempty"`
+	Name            string       `json:"name,omitempty"`
+	Settings        settingsDTO  `json:"settings,omitempty"`
+	TaxID           taxIDDTO     `json:"tax_id,omitempty"`
 }
 
-// CompensationConfig represents the compensation configuration for a company.
-type CompensationConfig struct {
+// compensationConfigDTO represents the compensation configuration for a company.
+type compensationConfigDTO struct {
 	HasSocialBenefits bool `json:"has_social_benefits"`
 }
 
-// CompanyResponse is the top-level structure for the company API response.
-type CompanyResponse struct {
-	Company            Company            `json:"company"`
-	CompensationConfig CompensationConfig `json:"compensation_config"`
+// companyResponse is the top-level structure for the company API response.
+type companyResponse struct {
+	Company            companyDTO            `json:"company"`
+	CompensationConfig compensationConfigDTO `json:"compensation_config"`
 }
 
 // GetCompany fetches the employee's company information from the Coverflex API.
 // It automatically handles token refresh if the current token is expired.
-// It returns a pointer to a CompanyResponse struct containing detailed information about the company
+// It returns a pointer to a Company struct containing detailed information about the company
 // or an error if the request fails or the response cannot be decoded.
-func (c *Client) GetCompany() (*CompanyResponse, error) {
+func (c *Client) GetCompany(ctx context.Context) (*domain.Company, error) {
 	slog.Info("Fetching employee company information...")
 
-	var response CompanyResponse
-	if err := c.get("https://menhir-api.coverflex.com/api/employee/company", &response); err != nil {
+	response, err := doRequest[companyResponse](ctx, c, apiRequest{
+		method: http.MethodGet,
+		url:    companyURL,
+		auth:   authSession,
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return &response, nil
+	company := toDomainCompany(*response)
+	return &company, nil
 }
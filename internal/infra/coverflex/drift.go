@@ -0,0 +1,280 @@
+package coverflex
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// DriftReport lists the differences between the fields returned by an endpoint
+// and the fields the client decodes from it.
+type DriftReport struct {
+	Endpoint string `json:"endpoint"`
+	// Unknown are the fields present in the response that the client ignores.
+	Unknown []string `json:"unknown_fields"`
+	// Missing are the fields the client expects that were absent from the response.
+	Missing []string `json:"missing_fields"`
+	// Mismatched are the fields whose JSON kind differs from the one the client decodes, such as an object
+	// where a string is expected, with both kinds.
+	Mismatched []string `json:"mismatched_fields"`
+}
+
+// HasDrift reports whether the endpoint response differs from the decoded structs.
+func (r DriftReport) HasDrift() bool {
+	return len(r.Unknown) > 0 || len(r.Missing) > 0 || len(r.Mismatched) > 0
+}
+
+// WithStrictDecoding enables the schema drift detector.
+// Every successful response is compared against the struct it is decoded into, and
+// the unknown, missing and mismatched fields are recorded per endpoint, even when they fail the decoding. A warning is logged the
+// first time a drifted field is seen. The recorded drift is available through Client.SchemaDrift.
+func WithStrictDecoding() ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.strictDecoding = true
+	}
+}
+
+// SchemaDrift returns the drift recorded for every endpoint inspected so far, sorted by endpoint.
+// It returns nil when strict decoding is not enabled.
+func (c *Client) SchemaDrift() []DriftReport {
+	if c.drift == nil {
+		return nil
+	}
+	return c.drift.reports()
+}
+
+// driftDetector accumulates the schema drift seen per endpoint.
+type driftDetector struct {
+	mu        sync.Mutex
+	endpoints map[string]*endpointDrift
+}
+
+type endpointDrift struct {
+	unknown    map[string]struct{}
+	missing    map[string]struct{}
+	mismatched map[string]struct{}
+}
+
+// driftFields are the drifted fields found in a single response.
+type driftFields struct {
+	unknown, missing, mismatched []string
+}
+
+func newDriftDetector() *driftDetector {
+	return &driftDetector{endpoints: make(map[string]*endpointDrift)}
+}
+
+// inspect compares the raw JSON body with the type it was decoded into and records the drift for the endpoint.
+func (d *driftDetector) inspect(method, rawURL string, body []byte, target reflect.Type) {
+	var raw any
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return
+	}
+
+	endpoint := method + " " + endpointPath(rawURL)
+	var fields driftFields
+	walkDrift("", raw, target, &fields)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	drift, ok := d.endpoints[endpoint]
+	if !ok {
+		drift = &endpointDrift{
+			unknown:    make(map[string]struct{}),
+			missing:    make(map[string]struct{}),
+			mismatched: make(map[string]struct{}),
+		}
+		d.endpoints[endpoint] = drift
+	}
+	newUnknown := addNew(drift.unknown, fields.unknown)
+	newMissing := addNew(drift.missing, fields.missing)
+	newMismatched := addNew(drift.mismatched, fields.mismatched)
+	if len(newUnknown) > 0 || len(newMissing) > 0 || len(newMismatched) > 0 {
+		slog.Warn("Coverflex API schema drift detected", "endpoint", endpoint,
+			"unknown_fields", newUnknown, "missing_fields", newMissing, "mismatched_fields", newMismatched)
+	}
+}
+
+func (d *driftDetector) reports() []DriftReport {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	reports := make([]DriftReport, 0, len(d.endpoints))
+	for _, endpoint := range slices.Sorted(maps.Keys(d.endpoints)) {
+		drift := d.endpoints[endpoint]
+		reports = append(reports, DriftReport{
+			Endpoint:   endpoint,
+			Unknown:    slices.Sorted(maps.Keys(drift.unknown)),
+			Missing:    slices.Sorted(maps.Keys(drift.missing)),
+			Mismatched: slices.Sorted(maps.Keys(drift.mismatched)),
+		})
+	}
+	return reports
+}
+
+// addNew adds the fields to the set and returns the ones that were not in it yet, sorted.
+func addNew(set map[string]struct{}, fields []string) []string {
+	var added []string
+	for _, field := range fields {
+		if _, ok := set[field]; !ok {
+			set[field] = struct{}{}
+			added = append(added, field)
+		}
+	}
+	slices.Sort(added)
+	return added
+}
+
+// endpointPath strips the host and query string from the URL, so all pages of an endpoint share the same report.
+func endpointPath(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Path
+}
+
+var jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+
+// wireKinder is implemented by the DTOs with a custom JSON decoding, such as timestamps, to tell the JSON kind
+// they decode.
+type wireKinder interface {
+	wireKind() string
+}
+
+var wireKinderType = reflect.TypeFor[wireKinder]()
+
+// walkDrift recursively compares a decoded JSON value with the Go type it maps to. Types with a custom JSON
+// decoding are leaves, whose JSON kind is only checked if they implement wireKinder.
+func walkDrift(path string, raw any, typ reflect.Type, fields *driftFields) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if raw == nil {
+		return
+	}
+	if want := expectedKind(typ); want != "" && want != kindOf(raw) {
+		fields.mismatched = append(fields.mismatched, fmt.Sprintf("%s (%s, want %s)", path, kindOf(raw), want))
+		return
+	}
+	if reflect.PointerTo(typ).Implements(jsonUnmarshalerType) {
+		return
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		object := raw.(map[string]any)
+		jsonFields := jsonFields(typ)
+		for key, value := range object {
+			field, ok := jsonFields[key]
+			if !ok {
+				fields.unknown = append(fields.unknown, joinPath(path, key))
+				continue
+			}
+			walkDrift(joinPath(path, key), value, field.Type, fields)
+		}
+		for key := range jsonFields {
+			if _, ok := object[key]; !ok {
+				fields.missing = append(fields.missing, joinPath(path, key))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for _, item := range raw.([]any) {
+			walkDrift(path+"[]", item, typ.Elem(), fields)
+		}
+	case reflect.Map:
+		for _, value := range raw.(map[string]any) {
+			walkDrift(joinPath(path, "*"), value, typ.Elem(), fields)
+		}
+	}
+}
+
+// expectedKind returns the JSON kind the type decodes, or an empty string if it decodes any kind or
+// has a custom decoding without wireKinder.
+func expectedKind(typ reflect.Type) string {
+	if reflect.PointerTo(typ).Implements(wireKinderType) {
+		return reflect.New(typ).Interface().(wireKinder).wireKind()
+	}
+	if reflect.PointerTo(typ).Implements(jsonUnmarshalerType) {
+		return ""
+	}
+	switch typ.Kind() {
+	case reflect.Struct, reflect.Map:
+		return "object"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	default:
+		return ""
+	}
+}
+
+// kindOf returns the JSON kind of a value decoded into any.
+func kindOf(raw any) string {
+	switch raw.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	default:
+		return "null"
+	}
+}
+
+// jsonFields returns the struct fields by their JSON name, flattening embedded structs like encoding/json does.
+func jsonFields(typ reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				maps.Copy(fields, jsonFields(embedded))
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field
+	}
+	return fields
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,59 @@
+package coverflex
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestDriftDetectorInspect(t *testing.T) {
+	body := `{"operations": {"list": [{
+		"id": "op1",
+		"amount": {"amount": "-12.34", "currency": "EUR"},
+		"category_slug": "meal",
+		"description_params": [],
+		"description_tag": "card_transaction",
+		"executed_at": {"iso": "2025-03-08T12:30:15Z"},
+		"is_debit": true,
+		"merchant_name": null,
+		"status": "confirmed",
+		"type": "card_transaction",
+		"cashback": 0
+	}], "total": 1}}`
+	detector := newDriftDetector()
+	detector.inspect("GET", operationsURL+"?page=2", []byte(body), reflect.TypeFor[operationsResponse]())
+
+	reports := detector.reports()
+	if len(reports) != 1 || reports[0].Endpoint != "GET /api/employee/operations" {
+		t.Fatalf("reports = %+v, want one for the operations endpoint without its query", reports)
+	}
+	report := reports[0]
+	if want := []string{"operations.list[].cashback", "operations.total"}; !slices.Equal(report.Unknown, want) {
+		t.Errorf("unknown = %q, want %q", report.Unknown, want)
+	}
+	// A null merchant is not missing, but an absent product is.
+	if want := []string{"operations.list[].product_slug"}; !slices.Equal(report.Missing, want) {
+		t.Errorf("missing = %q, want %q", report.Missing, want)
+	}
+	want := []string{
+		"operations.list[].amount.amount (string, want number)",
+		"operations.list[].executed_at (object, want string)",
+	}
+	if !slices.Equal(report.Mismatched, want) {
+		t.Errorf("mismatched = %q, want %q", report.Mismatched, want)
+	}
+	if !report.HasDrift() {
+		t.Errorf("HasDrift() = false, want true")
+	}
+}
+
+func TestDriftDetectorNoDrift(t *testing.T) {
+	body := `{"cards": [{"id": "c1", "activated_at": null, "expiration_date": "2028-05-31", "format": "virtual",
+		"holder_company_name": "ACME", "holder_name": "Ana", "is_expiring": false, "is_plastic_requested": false,
+		"network": "visa", "owner_id": "u1", "pan_last_digits": "1234", "provider_id": "p1", "status": "active", "version": "2"}]}`
+	detector := newDriftDetector()
+	detector.inspect("GET", cardsURL, []byte(body), reflect.TypeFor[cardsResponse]())
+	if reports := detector.reports(); len(reports) != 1 || reports[0].HasDrift() {
+		t.Errorf("reports = %+v, want the cards endpoint without drift", reports)
+	}
+}
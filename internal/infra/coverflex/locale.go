@@ -0,0 +1,85 @@
+package coverflex
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+// skipLocaleKey marks the request used to discover the default locale, so it does not try to resolve it again.
+type skipLocaleKey struct{}
+
+// WithLocale sets the locale sent in the Accept-Language header of every request.
+// Without this option, the first language of the company market is used.
+func WithLocale(locale string) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.locale = locale
+	}
+}
+
+// Locale returns the language requests made with ctx are sent in: the language set with domain.WithLanguage,
+// then the locale configured in the client, and finally the first language of the company market.
+// It returns an empty string when none of them is available, e.g. when the user is not logged in.
+func (c *Client) Locale(ctx context.Context) string {
+	if language, ok := domain.LanguageFrom(ctx); ok {
+		return language
+	}
+	if c.locale != "" {
+		return c.locale
+	}
+	if skip, _ := ctx.Value(skipLocaleKey{}).(bool); skip || !c.IsLoggedIn() {
+		return ""
+	}
+	return c.marketLocale(ctx)
+}
+
+// marketLocaleRetryDelay is how long the company market is not asked for its language again after it
+// could not be determined.
+const marketLocaleRetryDelay = time.Minute
+
+// marketLocale returns the first language of the company market, fetching it once and caching it.
+// Concurrent callers wait for the fetch in flight instead of requesting it again, but the lock is not held
+// during the request. For marketLocaleRetryDelay after a fetch fails, the locale is reported unknown.
+func (c *Client) marketLocale(ctx context.Context) string {
+	c.localeMu.Lock()
+	if c.defaultLocale != "" || time.Now().Before(c.localeRetryAt) {
+		defer c.localeMu.Unlock()
+		return c.defaultLocale
+	}
+	if fetch := c.localeFetch; fetch != nil {
+		c.localeMu.Unlock()
+		select {
+		case <-fetch:
+		case <-ctx.Done():
+			return ""
+		}
+		c.localeMu.Lock()
+		defer c.localeMu.Unlock()
+		return c.defaultLocale
+	}
+	fetch := make(chan struct{})
+	c.localeFetch = fetch
+	c.localeMu.Unlock()
+
+	company, err := c.GetCompany(context.WithValue(ctx, skipLocaleKey{}, true))
+
+	c.localeMu.Lock()
+	defer c.localeMu.Unlock()
+	defer close(fetch)
+	c.localeFetch = nil
+	if err != nil {
+		c.localeRetryAt = time.Now().Add(marketLocaleRetryDelay)
+		slog.Warn("Could not determine the default locale from the company market", "error", err, "retry_in", marketLocaleRetryDelay)
+		return ""
+	}
+	if len(company.Market.Languages) == 0 {
+		c.localeRetryAt = time.Now().Add(marketLocaleRetryDelay)
+		slog.Warn("The company market has no languages to use as default locale", "retry_in", marketLocaleRetryDelay)
+		return ""
+	}
+	c.defaultLocale = company.Market.Languages[0]
+	slog.Info("Using the company market language as default locale", "locale", c.defaultLocale)
+	return c.defaultLocale
+}
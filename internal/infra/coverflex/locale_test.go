@@ -0,0 +1,123 @@
+package coverflex
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+// loggedIn is a token repository with a session.
+type loggedIn struct{}
+
+func (loggedIn) GetTokens() (*domain.TokenPair, error) {
+	return &domain.TokenPair{AccessToken: "access", RefreshToken: "refresh"}, nil
+}
+func (loggedIn) SaveTokens(string, string) error { return nil }
+func (loggedIn) DeleteTokens() error             { return nil }
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{StatusCode: status, Header: http.Header{"Content-Type": {"application/json"}}, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+// companyServer answers the company endpoint once release is closed, and the cards endpoint with no cards,
+// recording the Accept-Language they were requested in.
+type companyServer struct {
+	started, release chan struct{}
+	status           int
+	companyCalls     atomic.Int32
+
+	mu        sync.Mutex
+	languages []string
+}
+
+func newCompanyServer(status int) *companyServer {
+	return &companyServer{started: make(chan struct{}, 1), release: make(chan struct{}), status: status}
+}
+
+func (s *companyServer) client() *Client {
+	client := NewClient(loggedIn{})
+	client.httpClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.String() == companyURL {
+			s.companyCalls.Add(1)
+			s.started <- struct{}{}
+			<-s.release
+			return jsonResponse(s.status, `{"company": {"market": {"languages": ["pt", "en"], "slug": "pt"}}}`), nil
+		}
+		s.mu.Lock()
+		s.languages = append(s.languages, req.Header.Get("Accept-Language"))
+		s.mu.Unlock()
+		return jsonResponse(http.StatusOK, `{"cards": []}`), nil
+	})}
+	return client
+}
+
+func TestLocaleWaitsForTheMarketFetch(t *testing.T) {
+	server := newCompanyServer(http.StatusOK)
+	client := server.client()
+
+	locales := make(chan string, 1)
+	go func() { locales <- client.Locale(t.Context()) }()
+	<-server.started
+
+	// Requests made while the company is fetched wait for its language.
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Go(func() {
+			if _, err := client.GetCards(t.Context()); err != nil {
+				t.Errorf("GetCards() error = %v", err)
+			}
+		})
+	}
+	close(server.release)
+	wg.Wait()
+
+	if locale := <-locales; locale != "pt" {
+		t.Errorf("Locale() = %q, want pt", locale)
+	}
+	if calls := server.companyCalls.Load(); calls != 1 {
+		t.Errorf("company fetched %d times, want once", calls)
+	}
+	if strings.Join(server.languages, ",") != "pt,pt,pt" {
+		t.Errorf("cards requested in %q, want pt every time", server.languages)
+	}
+}
+
+func TestLocaleBacksOffAfterAFailure(t *testing.T) {
+	server := newCompanyServer(http.StatusInternalServerError)
+	close(server.release)
+	client := server.client()
+
+	if locale := client.Locale(t.Context()); locale != "" {
+		t.Errorf("Locale() = %q, want none when the company cannot be fetched", locale)
+	}
+	if locale := client.Locale(t.Context()); locale != "" || server.companyCalls.Load() != 1 {
+		t.Errorf("Locale() = %q after %d fetches, want none without fetching again", locale, server.companyCalls.Load())
+	}
+	if locale := client.Locale(domain.WithLanguage(t.Context(), "es")); locale != "es" {
+		t.Errorf("Locale() = %q, want the language of the context", locale)
+	}
+}
+
+func TestLocaleWaitStopsWithTheContext(t *testing.T) {
+	server := newCompanyServer(http.StatusOK)
+	client := server.client()
+	go client.Locale(t.Context())
+	<-server.started
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	if locale := client.Locale(ctx); locale != "" {
+		t.Errorf("Locale() = %q, want none once the context is done", locale)
+	}
+	close(server.release)
+}
@@ -1,59 +1,37 @@
 package coverflex
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
 	"log/slog"
 	"net/http"
 )
 
 // RequestOTP initiates the login process by requesting an OTP.
-func (c *Client) RequestOTP(email, password string) error {
-	payload := sessionRequest{Email: email, Password: password}
-	payloadBytes, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("error creating JSON payload: %w", err)
-	}
-
-	req, err := http.NewRequest("POST", sessionURL, bytes.NewBuffer(payloadBytes))
-	if err != nil {
-		return fmt.Errorf("error creating request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.httpClient.Do(req)
+func (c *Client) RequestOTP(ctx context.Context, email, password string) error {
+	otpResp, err := doRequest[otpResponse](ctx, c, apiRequest{
+		method: http.MethodPost,
+		url:    sessionURL,
+		body:   sessionRequest{Email: email, Password: password},
+		auth:   authNone,
+		expect: []int{http.StatusAccepted},
+	})
 	if err != nil {
 		return fmt.Errorf("error during OTP request: %w", err)
 	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			slog.Warn("failed to close response body", "error", err)
-		}
-	}()
-
-	if resp.StatusCode == http.StatusAccepted { // 202
-		var otpResp otpResponse
-		if err := json.NewDecoder(resp.Body).Decode(&otpResp); err != nil {
-			return fmt.Errorf("error decoding OTP response: %w", err)
-		}
-		slog.Info("OTP sent to phone", "phone_last_digits", otpResp.PhoneLastDigits)
-		return nil
-	}
 
-	bodyBytes, _ := io.ReadAll(resp.Body)
-	return fmt.Errorf("unexpected status code during OTP request: %d\nResponse: %s", resp.StatusCode, string(bodyBytes))
+	slog.Info("OTP sent to phone", "phone_last_digits", otpResp.PhoneLastDigits)
+	return nil
 }
 
 // Login completes the authentication process using the provided OTP.
-func (c *Client) Login(email, password, otp string) error {
-	authToken, refreshToken, err := c.submitOTP(email, password, otp)
+func (c *Client) Login(ctx context.Context, email, password, otp string) error {
+	authToken, refreshToken, err := c.submitOTP(ctx, email, password, otp)
 	if err != nil {
 		return err
 	}
 
-	authToken, refreshToken = c.trustDevice(authToken, refreshToken)
+	authToken, refreshToken = c.trustDevice(ctx, authToken, refreshToken)
 
 	if err := c.tokenRepo.SaveTokens(authToken, refreshToken); err != nil {
 		return fmt.Errorf("error saving tokens: %w", err)
@@ -62,39 +40,18 @@ func (c *Client) Login(email, password, otp string) error {
 	return nil
 }
 
-func (c *Client) submitOTP(email, password, otp string) (string, string, error) {
+func (c *Client) submitOTP(ctx context.Context, email, password, otp string) (string, string, error) {
 	slog.Info("Submitting OTP...")
-	payload := sessionRequest{Email: email, Password: password, OTP: otp}
-	payloadBytes, err := json.Marshal(payload)
-	if err != nil {
-		return "", "", fmt.Errorf("error creating OTP payload: %w", err)
-	}
-
-	req, err := http.NewRequest("POST", sessionURL, bytes.NewBuffer(payloadBytes))
-	if err != nil {
-		return "", "", fmt.Errorf("error creating token request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.httpClient.Do(req)
+	tokens, err := doRequest[tokenResponse](ctx, c, apiRequest{
+		method: http.MethodPost,
+		url:    sessionURL,
+		body:   sessionRequest{Email: email, Password: password, OTP: otp},
+		auth:   authNone,
+		expect: []int{http.StatusCreated},
+	})
 	if err != nil {
 		return "", "", fmt.Errorf("error during token request: %w", err)
 	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			slog.Warn("failed to close response body", "error", err)
-		}
-	}()
-
-	if resp.StatusCode != http.StatusCreated { // 201
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return "", "", fmt.Errorf("unexpected status code during token request: %d\nResponse: %s", resp.StatusCode, string(bodyBytes))
-	}
-
-	var tokens tokenResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
-		return "", "", fmt.Errorf("error decoding token response: %w", err)
-	}
 
 	if tokens.Token == "" {
 		return "", "", fmt.Errorf("failed to retrieve auth token")
@@ -104,37 +61,59 @@ func (c *Client) submitOTP(email, password, otp string) (string, string, error)
 	return tokens.Token, tokens.RefreshToken, nil
 }
 
-func (c *Client) trustDevice(authToken, refreshToken string) (string, string) {
+func (c *Client) trustDevice(ctx context.Context, authToken, refreshToken string) (string, string) {
 	slog.Info("Trusting this device...")
-	req, err := http.NewRequest("POST", trustURL, nil)
+	newTokens, err := doRequest[tokenResponse](ctx, c, apiRequest{
+		method: http.MethodPost,
+		url:    trustURL,
+		auth:   authBearer,
+		token:  authToken,
+		expect: []int{http.StatusCreated},
+	})
 	if err != nil {
-		slog.Warn("Error creating trust request", "error", err)
+		slog.Warn("Error trusting device", "error", err)
 		return authToken, refreshToken
 	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+authToken)
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		slog.Warn("Error trusting device", "error", err)
+	slog.Info("Device trusted successfully.")
+	if newTokens.Token == "" {
 		return authToken, refreshToken
 	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			slog.Warn("failed to close response body", "error", err)
-		}
-	}()
-
-	if resp.StatusCode == http.StatusCreated { // 201
-		slog.Info("Device trusted successfully.")
-		var newTokens tokenResponse
-		if err := json.NewDecoder(resp.Body).Decode(&newTokens); err == nil {
-			if newTokens.UserAgentToken != "" {
-				slog.Info("Received user agent token for long-term session.")
-			}
-			return newTokens.Token, newTokens.RefreshToken
-		}
+	if newTokens.UserAgentToken != "" {
+		slog.Info("Received user agent token for long-term session.")
+	}
+	return newTokens.Token, newTokens.RefreshToken
+}
+
+// RefreshTokens handles the token refresh logic.
+func (c *Client) RefreshTokens(ctx context.Context, refreshToken string) (newAuthToken, newRefreshToken string) {
+	slog.Info("Attempting to refresh tokens...")
+
+	renewedTokens, err := doRequest[renewTokenResponse](ctx, c, apiRequest{
+		method: http.MethodPost,
+		url:    refreshURL,
+		auth:   authBearer, // Refresh token in header, never refreshed itself
+		token:  refreshToken,
+		expect: []int{http.StatusOK, http.StatusCreated},
+	})
+	if err != nil {
+		slog.Error("Error during token refresh request", "error", err)
+		return "", ""
+	}
+
+	newAuthToken = renewedTokens.Data.AccessToken
+	newRefreshToken = renewedTokens.Data.RefreshToken
+
+	if newAuthToken == "" || newRefreshToken == "" {
+		slog.Error("Failed to retrieve new tokens from refresh response.")
+		return "", ""
+	}
+
+	if err := c.tokenRepo.SaveTokens(newAuthToken, newRefreshToken); err != nil {
+		slog.Error("Error saving new tokens", "error", err)
+		// Continue anyway, as we have the tokens in memory
 	}
 
-	return authToken, refreshToken
+	slog.Info("Tokens refreshed and saved successfully.")
+	return newAuthToken, newRefreshToken
 }
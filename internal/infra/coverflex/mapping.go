@@ -0,0 +1,150 @@
+package coverflex
+
+import "github.com/tembleking/coverflex-mcp/internal/domain"
+
+// The mappers below translate the Coverflex wire format into domain entities, so a change in the
+// API only needs to be handled here and never leaks into the MCP tool schemas.
+
+func mapSlice[DTO, Entity any](dtos []DTO, mapper func(DTO) Entity) []Entity {
+	entities := make([]Entity, 0, len(dtos))
+	for _, dto := range dtos {
+		entities = append(entities, mapper(dto))
+	}
+	return entities
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func toDomainBenefit(dto benefitDTO) domain.Benefit {
+	return domain.Benefit{
+		ID:          dto.ID,
+		Name:        dto.Name,
+		Slug:        dto.Slug,
+		Description: derefString(dto.Description),
+		Limits: domain.BenefitLimits{
+			Monthly: toDomainOptionalMoney(dto.Limits.Monthly),
+			Yearly:  toDomainOptionalMoney(dto.Limits.Yearly),
+		},
+		Products: mapSlice(dto.Products, toDomainProduct),
+	}
+}
+
+func toDomainProduct(dto productDTO) domain.Product {
+	return domain.Product{
+		ID:          dto.ID,
+		Name:        dto.Name,
+		Slug:        dto.Slug,
+		Description: dto.Description,
+		Status:      dto.Status,
+		Type:        dto.Type,
+	}
+}
+
+func toDomainCard(dto cardDTO) domain.Card {
+	return domain.Card{
+		ID:                 dto.ID,
+		ActivatedAt:        toDomainOptionalTimestamp(dto.ActivatedAt),
+		ExpirationDate:     toDomainDate(dto.ExpirationDate),
+		Format:             dto.Format,
+		HolderCompanyName:  dto.HolderCompanyName,
+		HolderName:         dto.HolderName,
+		IsExpiring:         dto.IsExpiring,
+		IsPlasticRequested: dto.IsPlasticRequested,
+		Network:            dto.Network,
+		OwnerID:            dto.OwnerID,
+		PANLastDigits:      dto.PANLastDigits,
+		ProviderID:         dto.ProviderID,
+		Status:             dto.Status,
+		Version:            dto.Version,
+	}
+}
+
+func toDomainCompany(dto companyResponse) domain.Company {
+	company := dto.Company
+	return domain.Company{
+		ID:              company.ID,
+		Name:            company.Name,
+		LegalName:       company.LegalName,
+		CardDisplayName: company.CardDisplayName,
+		LogoURI:         derefString(company.LogoURI),
+		Addresses:       mapSlice(company.Addresses, toDomainAddress),
+		Market: domain.Market{
+			Languages: company.Market.Languages,
+			Slug:      company.Market.Slug,
+		},
+		Settings: domain.CompanySettings{
+			CardRequestEmployeePermission:  company.Settings.CardRequestEmployeePermission,
+			CardRequestFormat:              company.Settings.CardRequestFormat,
+			CardRequestStrategy:            company.Settings.CardRequestStrategy,
+			CardShippingStrategy:           company.Settings.CardShippingStrategy,
+			IncludeEmployeeNumberInReports: company.Settings.IncludeEmployeeNumberInReports,
+			KinshipDegreeProofRequired:     company.Settings.KinshipDegreeProofRequired,
+			Plan:                           company.Settings.Plan,
+			SavingsEmployeeEnabled:         company.Settings.SavingsEmployeeEnabled,
+		},
+		TaxID: domain.TaxID{
+			Type:  company.TaxID.Type,
+			Value: company.TaxID.Value,
+		},
+		HasSocialBenefits: dto.CompensationConfig.HasSocialBenefits,
+	}
+}
+
+func toDomainAddress(dto addressDTO) domain.Address {
+	return domain.Address{
+		AddressLine1: dto.AddressLine1,
+		AddressLine2: derefString(dto.AddressLine2),
+		City:         dto.City,
+		Country:      dto.Country,
+		District:     dto.District,
+		Type:         dto.Type,
+		Zipcode:      dto.Zipcode,
+	}
+}
+
+func toDomainCompensation(dto compensationSummaryDTO) domain.Compensation {
+	return domain.Compensation{
+		Attributions: mapSlice(dto.Attributions, func(attribution attributionDTO) domain.Attribution {
+			return domain.Attribution{ID: attribution.ID, Slug: attribution.Slug, Balance: toDomainMoney(attribution.Balance)}
+		}),
+		Benefits: mapSlice(dto.Benefits, func(benefit compensationBenefitDTO) domain.CompensationBenefit {
+			return domain.CompensationBenefit{Slug: benefit.Slug, Balance: toDomainMoney(benefit.Balance)}
+		}),
+		RenewalDate: toDomainDate(dto.RenewalDate),
+		Status:      dto.Status,
+	}
+}
+
+func toDomainFamilyMember(dto familyMemberDTO) domain.FamilyMember {
+	return domain.FamilyMember{
+		ID:           dto.ID,
+		FullName:     dto.FullName,
+		ShortName:    dto.ShortName,
+		BirthDate:    toDomainDate(dto.BirthDate),
+		RelationType: dto.RelationType,
+		Gender:       dto.Gender,
+	}
+}
+
+func toDomainOperation(dto operationDTO) domain.Operation {
+	return domain.Operation{
+		ID:             dto.ID,
+		Amount:         toDomainMoney(dto.Amount),
+		DescriptionTag: dto.DescriptionTag,
+		DescriptionParams: mapSlice(dto.DescriptionParams, func(param descriptionParamDTO) domain.DescriptionParam {
+			return domain.DescriptionParam{Key: param.Key, Value: param.Value}
+		}),
+		CategorySlug: domain.CategorySlug(dto.CategorySlug),
+		ProductSlug:  dto.ProductSlug,
+		ExecutedAt:   toDomainTimestamp(dto.ExecutedAt),
+		IsDebit:      dto.IsDebit,
+		MerchantName: derefString(dto.MerchantName),
+		Status:       domain.OperationStatus(dto.Status),
+		Type:         domain.OperationType(dto.Type),
+	}
+}
@@ -0,0 +1,65 @@
+package coverflex
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+func TestToDomainOperationFromWire(t *testing.T) {
+	body := `{"operations": {"list": [{
+		"id": "op1",
+		"amount": {"amount": -1234, "currency": "eur"},
+		"category_slug": "meal",
+		"description_params": [{"key": "merchant_name", "value": "Pingo Doce"}],
+		"description_tag": "card_transaction",
+		"executed_at": "2025-03-08T12:30:15Z",
+		"is_debit": true,
+		"merchant_name": null,
+		"product_slug": "meal",
+		"status": "confirmed",
+		"type": "card_transaction"
+	}]}}`
+	var response operationsResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	operation := toDomainOperation(response.Operations.List[0])
+	if operation.Amount != domain.NewMoney(-1234, "EUR") {
+		t.Errorf("amount = %+v, want -1234 EUR minor units", operation.Amount)
+	}
+	if want := time.Date(2025, time.March, 8, 12, 30, 15, 0, time.UTC); !operation.ExecutedAt.Equal(want) {
+		t.Errorf("executed at = %s, want %s", operation.ExecutedAt, want)
+	}
+	if operation.MerchantName != "" || operation.Status != domain.OperationStatusConfirmed || operation.CategorySlug != domain.CategoryMeal {
+		t.Errorf("operation = %+v, want a confirmed meal operation without merchant", operation)
+	}
+}
+
+func TestWireTimesAreStrings(t *testing.T) {
+	var card cardDTO
+	if err := json.Unmarshal([]byte(`{"activated_at": null, "expiration_date": "2028-05-31"}`), &card); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if mapped := toDomainCard(card); mapped.ActivatedAt != nil || mapped.ExpirationDate != domain.NewDate(2028, time.May, 31) {
+		t.Errorf("card activated at %v, expiring %s, want not activated and expiring on 2028-05-31", mapped.ActivatedAt, mapped.ExpirationDate)
+	}
+
+	// The output shape of the domain types is not a wire format.
+	for _, body := range []string{
+		`{"executed_at": {"iso": "2025-03-08T12:30:15Z"}}`,
+		`{"executed_at": "08/03/2025"}`,
+	} {
+		var operation operationDTO
+		if err := json.Unmarshal([]byte(body), &operation); err == nil {
+			t.Errorf("Unmarshal(%s) succeeded, want an error", body)
+		}
+	}
+	var member familyMemberDTO
+	if err := json.Unmarshal([]byte(`{"birth_date": {"iso": "2020-01-02"}}`), &member); err == nil {
+		t.Errorf("Unmarshal() of a birth date object succeeded, want an error")
+	}
+}
@@ -0,0 +1,23 @@
+package coverflex
+
+import "github.com/tembleking/coverflex-mcp/internal/domain"
+
+// moneyDTO is an amount as the API sends it, with "amount" holding the minor units of the currency,
+// e.g. {"amount": 1234, "currency": "EUR"} for 12,34 €.
+type moneyDTO struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func toDomainMoney(dto moneyDTO) domain.Money {
+	return domain.NewMoney(dto.Amount, dto.Currency)
+}
+
+// toDomainOptionalMoney maps an amount the API may leave out, such as a benefit without a limit.
+func toDomainOptionalMoney(dto *moneyDTO) *domain.Money {
+	if dto == nil {
+		return nil
+	}
+	money := toDomainMoney(*dto)
+	return &money
+}
@@ -1,155 +1,116 @@
 package coverflex
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
+	"iter"
 	"log/slog"
 	"net/http"
 	"net/url"
 	"strconv"
+
+	"github.com/tembleking/coverflex-mcp/internal/domain"
 )
 
-// Amount represents a monetary value and its currency.
-type Amount struct {
-	Amount   int    `json:"amount"`
-	Currency string `json:"currency"`
-}
+// DefaultAllOperationsPerPage is the page size AllOperations requests by default.
+const DefaultAllOperationsPerPage = 50
+
+// DefaultFilteredMaxPages caps the pages walked to fill a page filtered client-side, unless the options set
+// their own limit, so a filter matching few operations does not walk the whole history.
+const DefaultFilteredMaxPages = 20
 
-// DescriptionParam provides details for an operation's description.
-type DescriptionParam struct {
-	Key   string      `json:"key"`
-	Value interface{} `json:"value"`
+// descriptionParamDTO provides details for an operation's description.
+type descriptionParamDTO struct {
+	Key   string `json:"key"`
+	Value any    `json:"value"`
 }
 
-// Operation represents a single financial operation.
-type Operation struct {
-	ID                string             `json:"id"`
-	Amount            Amount             `json:"amount"`
-	CategorySlug      string             `json:"category_slug"`
-	DescriptionParams []DescriptionParam `json:"description_params"`
-	DescriptionTag    string             `json:"description_tag"`
-	ExecutedAt        string             `json:"executed_at"`
-	IsDebit           bool               `json:"is_debit"`
-	MerchantName      *string            `json:"merchant_name"`
-	ProductSlug       string             `json:"product_slug"`
-	Status            string             `json:"status"`
-	Type              string             `json:"type"`
+// operationDTO represents a single financial operation.
+type operationDTO struct {
+	ID                string                `json:"id"`
+	Amount            moneyDTO              `json:"amount"`
+	CategorySlug      string                `json:"category_slug"`
+	DescriptionParams []descriptionParamDTO `json:"description_params"`
+	DescriptionTag    string                `json:"description_tag"`
+	ExecutedAt        timestampDTO          `json:"executed_at"`
+	IsDebit           bool                  `json:"is_debit"`
+	MerchantName      *string               `json:"merchant_name"`
+	ProductSlug       string                `json:"product_slug"`
+	Status            string                `json:"status"`
+	Type              string                `json:"type"`
 }
 
-// OperationsResponse is the top-level structure for the operations API response.
-type OperationsResponse struct {
+// operationsResponse is the top-level structure for the operations API response.
+type operationsResponse struct {
 	Operations struct {
-		List []Operation `json:"list"`
+		List []operationDTO `json:"list"`
 	} `json:"operations"`
 }
 
-// OperationsFilters holds the filter parameters for GetOperations.
-type OperationsFilters struct {
-	Type string
-}
-
-// GetOperationsParams holds the parameters for the GetOperations method.
-type GetOperationsParams struct {
-	Page    int
-	PerPage int
-	Filters OperationsFilters
-}
-
-// GetOperationsOption defines a function that modifies GetOperationsParams.
-type GetOperationsOption func(*GetOperationsParams)
-
-// WithOperationsPage sets the page number for the operations request.
-func WithOperationsPage(page int) GetOperationsOption {
-	return func(params *GetOperationsParams) {
-		if page > 0 {
-			params.Page = page
-		}
-	}
-}
-
-// WithOperationsPerPage sets the number of operations to return per page.
-func WithOperationsPerPage(perPage int) GetOperationsOption {
-	return func(params *GetOperationsParams) {
-		if perPage > 0 {
-			params.PerPage = perPage
-		}
-	}
+// operationResponse is the top-level structure for the single operation API response.
+type operationResponse struct {
+	Operation operationDTO `json:"operation"`
 }
 
-// WithOperationsFilterType sets the type filter for the operations request.
-func WithOperationsFilterType(filterType string) GetOperationsOption {
-	return func(params *GetOperationsParams) {
-		params.Filters.Type = filterType
-	}
-}
+// GetOperation fetches a single operation from its endpoint. A 404 Not Found status is reported as
+// domain.ErrOperationNotFound. The endpoint and its response shape are inferred from the operations
+// endpoint, so callers should fall back to other sources on any error.
+func (c *Client) GetOperation(ctx context.Context, id string) (*domain.Operation, error) {
+	slog.Info("Fetching operation...", "id", id)
 
-// RefreshTokens handles the token refresh logic.
-func (c *Client) RefreshTokens(refreshToken string) (newAuthToken, newRefreshToken string) {
-	slog.Info("Attempting to refresh tokens...")
-
-	req, err := http.NewRequest("POST", refreshURL, nil) // No body for this request
-	if err != nil {
-		slog.Error("Error creating refresh request", "error", err)
-		return "", ""
+	response, err := doRequest[operationResponse](ctx, c, apiRequest{
+		method: http.MethodGet,
+		url:    operationsURL + "/" + url.PathEscape(id),
+		auth:   authSession,
+	})
+	if IsStatus(err, http.StatusNotFound) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrOperationNotFound, id)
 	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+refreshToken) // Token in header
-
-	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		slog.Error("Error during token refresh request", "error", err)
-		return "", ""
-	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			slog.Warn("failed to close refresh response body", "error", err)
-		}
-	}()
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated { // 200 or 201
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		slog.Error("Unexpected status code during token refresh", "status_code", resp.StatusCode, "response", string(bodyBytes))
-		return "", ""
+		return nil, err
 	}
-
-	var renewedTokens renewTokenResponse
-	if err := json.NewDecoder(resp.Body).Decode(&renewedTokens); err != nil {
-		slog.Error("Error decoding refreshed token response", "error", err)
-		return "", ""
+	if response.Operation.ID != id {
+		return nil, fmt.Errorf("unexpected operation in response: %q", response.Operation.ID)
 	}
 
-	newAuthToken = renewedTokens.Data.AccessToken
-	newRefreshToken = renewedTokens.Data.RefreshToken
+	operation := toDomainOperation(response.Operation)
+	return &operation, nil
+}
 
-	if newAuthToken == "" || newRefreshToken == "" {
-		slog.Error("Failed to retrieve new tokens from refresh response.")
-		return "", ""
+// GetOperations fetches financial operations from the Coverflex API.
+// It supports pagination and filtering through functional options.
+// The API only filters by a single type, so when other filters are given the pages are walked from the
+// first one and filtered client-side, stopping once past the period or after DefaultFilteredMaxPages pages,
+// in which case the operations found so far are returned along with domain.ErrPageLimitReached.
+// It automatically handles token refresh if the current token is expired.
+// It returns a slice of Operation structs or an error if the request fails.
+func (c *Client) GetOperations(ctx context.Context, opts ...domain.GetOperationsOption) ([]domain.Operation, error) {
+	if domain.NewGetOperationsParams(opts...).Filters.HasClientSide() {
+		return domain.FilteredOperationsPage(ctx, c.fetchOperationsPage, withFilteredMaxPages(opts)...)
 	}
+	return c.fetchOperationsPage(ctx, opts...)
+}
 
-	if err := c.tokenRepo.SaveTokens(newAuthToken, newRefreshToken); err != nil {
-		slog.Error("Error saving new tokens", "error", err)
-		// Continue anyway, as we have the tokens in memory
+// GetOperationsAfter fetches the page of operations right after the cursor, resuming at the page it hints
+// and skipping the operations already returned. The filters are applied as in GetOperations, and a page cut
+// short by DefaultFilteredMaxPages is marked partial.
+func (c *Client) GetOperationsAfter(ctx context.Context, cursor *domain.OperationsCursor, opts ...domain.GetOperationsOption) (*domain.OperationsCursorPage, error) {
+	if domain.NewGetOperationsParams(opts...).Filters.HasClientSide() {
+		opts = withFilteredMaxPages(opts)
 	}
+	return domain.OperationsPageAfter(ctx, c.fetchOperationsPage, cursor, opts...)
+}
 
-	slog.Info("Tokens refreshed and saved successfully.")
-	return newAuthToken, newRefreshToken
+// withFilteredMaxPages puts DefaultFilteredMaxPages before the options, so a limit they set takes precedence.
+func withFilteredMaxPages(opts []domain.GetOperationsOption) []domain.GetOperationsOption {
+	return append([]domain.GetOperationsOption{domain.WithOperationsMaxPages(DefaultFilteredMaxPages)}, opts...)
 }
 
-// GetOperations fetches financial operations from the Coverflex API.
-// It supports pagination and filtering through functional options.
-// It automatically handles token refresh if the current token is expired.
-// It returns a slice of Operation structs or an error if the request fails.
-func (c *Client) GetOperations(opts ...GetOperationsOption) ([]Operation, error) {
+// fetchOperationsPage fetches a single page of operations, with the filters supported by the API.
+func (c *Client) fetchOperationsPage(ctx context.Context, opts ...domain.GetOperationsOption) ([]domain.Operation, error) {
 	slog.Info("Fetching recent operations...")
 
-	params := &GetOperationsParams{
-		Page:    1,
-		PerPage: 20,
-	}
-	for _, opt := range opts {
-		opt(params)
-	}
+	params := domain.NewGetOperationsParams(opts...)
 
 	baseURL, err := url.Parse(operationsURL)
 	if err != nil {
@@ -162,15 +123,27 @@ func (c *Client) GetOperations(opts ...GetOperationsOption) ([]Operation, error)
 	if params.PerPage > 0 {
 		queryParams.Add("per_page", strconv.Itoa(params.PerPage))
 	}
-	if params.Filters.Type != "" {
-		queryParams.Add(fmt.Sprintf("filters[%s]", "type"), params.Filters.Type)
+	if operationType, ok := params.Filters.ServerSideType(); ok {
+		queryParams.Add(fmt.Sprintf("filters[%s]", "type"), string(operationType))
 	}
 	baseURL.RawQuery = queryParams.Encode()
 
-	var response OperationsResponse
-	if err := c.get(baseURL.String(), &response); err != nil {
+	response, err := doRequest[operationsResponse](ctx, c, apiRequest{
+		method: http.MethodGet,
+		url:    baseURL.String(),
+		auth:   authSession,
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return response.Operations.List, nil
+	return mapSlice(response.Operations.List, toDomainOperation), nil
+}
+
+// AllOperations iterates over every operation, fetching the pages on demand from the configured page
+// until the API runs out of operations or the MaxPages cap is reached.
+// Pages of DefaultAllOperationsPerPage operations are requested unless WithOperationsPerPage is given.
+func (c *Client) AllOperations(ctx context.Context, opts ...domain.GetOperationsOption) iter.Seq2[domain.Operation, error] {
+	opts = append([]domain.GetOperationsOption{domain.WithOperationsPerPage(DefaultAllOperationsPerPage)}, opts...)
+	return domain.PaginateOperations(ctx, c.fetchOperationsPage, opts...)
 }
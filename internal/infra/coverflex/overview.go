@@ -0,0 +1,87 @@
+package coverflex
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+// GetOverview fetches the company, compensation, benefits, cards, family and recent operations concurrently,
+// with at most Parallelism requests in flight.
+// A failing section does not fail the whole overview: its error is reported in Overview.Errors
+// and the remaining sections are still returned.
+func (c *Client) GetOverview(ctx context.Context, opts ...domain.GetOverviewOption) *domain.Overview {
+	slog.Info("Fetching account overview...")
+
+	params := &domain.GetOverviewParams{
+		Parallelism: domain.DefaultOverviewParallelism,
+	}
+	for _, opt := range opts {
+		opt(params)
+	}
+
+	overview := &domain.Overview{}
+	sections := map[string]func(context.Context) error{
+		"company": func(ctx context.Context) (err error) {
+			overview.Company, err = c.GetCompany(ctx)
+			return err
+		},
+		"compensation": func(ctx context.Context) (err error) {
+			overview.Compensation, err = c.GetCompensation(ctx)
+			return err
+		},
+		"benefits": func(ctx context.Context) (err error) {
+			overview.Benefits, err = c.GetBenefits(ctx)
+			return err
+		},
+		"cards": func(ctx context.Context) (err error) {
+			overview.Cards, err = c.GetCards(ctx)
+			return err
+		},
+		"family": func(ctx context.Context) (err error) {
+			overview.Family, err = c.GetFamily(ctx)
+			return err
+		},
+		"operations": func(ctx context.Context) (err error) {
+			operations, err := c.GetOperations(ctx, params.OperationsOptions...)
+			// A page cut short by the page limit is still worth showing.
+			if err != nil && !errors.Is(err, domain.ErrPageLimitReached) {
+				return err
+			}
+			overview.Operations = domain.LinkRefunds(operations)
+			return nil
+		},
+	}
+
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		sem = make(chan struct{}, params.Parallelism)
+	)
+	for name, fetch := range sections {
+		wg.Go(func() {
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				mu.Lock()
+				defer mu.Unlock()
+				overview.SetError(name, ctx.Err())
+				return
+			}
+
+			if err := fetch(ctx); err != nil {
+				slog.Warn("Failed to fetch overview section", "section", name, "error", err)
+				mu.Lock()
+				defer mu.Unlock()
+				overview.SetError(name, err)
+			}
+		})
+	}
+	wg.Wait()
+
+	return overview
+}
@@ -0,0 +1,247 @@
+package coverflex
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// maxErrorBodySize caps how much of an error response body is read and reported.
+const maxErrorBodySize = 64 << 10
+
+// authMode defines how a request is authenticated against the Coverflex API.
+type authMode int
+
+const (
+	// authNone sends the request without an authorization header.
+	authNone authMode = iota
+	// authSession uses the stored access token, refreshing it and retrying once on a 401 Unauthorized status.
+	authSession
+	// authBearer uses the explicit token of the request and never refreshes it.
+	authBearer
+)
+
+// apiRequest describes a single call to the Coverflex API.
+type apiRequest struct {
+	method string
+	url    string
+	// body is encoded as JSON when not nil.
+	body any
+	auth authMode
+	// token is the bearer token used with authBearer.
+	token string
+	// expect lists the accepted status codes. It defaults to 200 OK.
+	expect []int
+}
+
+// APIError is returned when the Coverflex API answers with an unexpected status code.
+type APIError struct {
+	StatusCode int
+	// Message is the error message decoded from the response body, if any.
+	Message string
+	// Body is the raw response body.
+	Body string
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("unexpected status code: %d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("unexpected status code: %d\nResponse: %s", e.StatusCode, e.Body)
+}
+
+// IsStatus reports whether err is an APIError with the given status code.
+func IsStatus(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
+// doRequest is the single pipeline used for every call to the Coverflex API.
+// It encodes the JSON body, sets the authorization headers, performs the request, refreshes
+// the session and retries once on a 401 Unauthorized status, checks the status code against
+// the expected ones, and decodes either the response into T or the error body into an APIError.
+func doRequest[T any](ctx context.Context, c *Client, r apiRequest) (*T, error) {
+	var payload []byte
+	if r.body != nil {
+		var err error
+		payload, err = json.Marshal(r.body)
+		if err != nil {
+			return nil, fmt.Errorf("error creating JSON payload: %w", err)
+		}
+	}
+
+	token := r.token
+	var refreshToken string
+	if r.auth == authSession {
+		tokens, err := c.tokenRepo.GetTokens()
+		if err != nil {
+			return nil, fmt.Errorf("not logged in: %w", err)
+		}
+		token, refreshToken = tokens.AccessToken, tokens.RefreshToken
+	}
+
+	resp, err := c.send(ctx, r, payload, token)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp)
+
+	// Handle token refresh and retry
+	if r.auth == authSession && resp.StatusCode == http.StatusUnauthorized {
+		newAuthToken := c.refreshSession(ctx, token, refreshToken)
+		if newAuthToken == "" {
+			_ = c.tokenRepo.DeleteTokens()
+			return nil, fmt.Errorf("token refresh failed")
+		}
+
+		slog.Info("Retrying request with new token...")
+		resp, err = c.send(ctx, r, payload, newAuthToken)
+		if err != nil {
+			return nil, fmt.Errorf("error performing retry request: %w", err)
+		}
+		defer closeBody(resp)
+	}
+
+	expect := r.expect
+	if len(expect) == 0 {
+		expect = []int{http.StatusOK}
+	}
+	if !slices.Contains(expect, resp.StatusCode) {
+		return nil, decodeAPIError(resp)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+
+	target := new(T)
+	if len(bytes.TrimSpace(body)) == 0 {
+		return target, nil
+	}
+	err = json.Unmarshal(body, target)
+	// The drift is inspected even if the decoding failed, as a changed field is the likely cause.
+	if c.drift != nil {
+		c.drift.inspect(r.method, r.url, body, reflect.TypeFor[T]())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+	return target, nil
+}
+
+// refreshSession renews the session after the given access token was rejected.
+// Concurrent requests share a single refresh: if another request already renewed the
+// session, its new access token is reused instead of refreshing again.
+func (c *Client) refreshSession(ctx context.Context, rejectedToken, refreshToken string) string {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	if tokens, err := c.tokenRepo.GetTokens(); err == nil && tokens.AccessToken != rejectedToken {
+		return tokens.AccessToken
+	}
+
+	slog.Info("Token expired. Refreshing...")
+	newAuthToken, _ := c.RefreshTokens(ctx, refreshToken)
+	return newAuthToken
+}
+
+// send builds and performs a single HTTP request.
+func (c *Client) send(ctx context.Context, r apiRequest, payload []byte, token string) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.method, r.url, body)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json, text/plain, */*")
+	if payload != nil || r.method != http.MethodGet {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if r.auth != authNone && token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if r.auth == authSession {
+		if locale := c.Locale(ctx); locale != "" {
+			req.Header.Set("Accept-Language", locale)
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing request: %w", err)
+	}
+	return resp, nil
+}
+
+// decodeAPIError turns an unexpected response into an APIError, extracting the error message when the body is JSON.
+func decodeAPIError(resp *http.Response) error {
+	bodyBytes, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       string(bodyBytes),
+	}
+
+	var errorBody struct {
+		Message string `json:"message"`
+		Error   any    `json:"error"`
+		Errors  any    `json:"errors"`
+	}
+	if err := json.Unmarshal(bodyBytes, &errorBody); err == nil {
+		switch {
+		case errorBody.Message != "":
+			apiErr.Message = errorBody.Message
+		case errorBody.Error != nil:
+			apiErr.Message = errorMessage(errorBody.Error)
+		case errorBody.Errors != nil:
+			apiErr.Message = errorMessage(errorBody.Errors)
+		}
+	}
+	return apiErr
+}
+
+// errorMessage flattens the different shapes an API error can take into a single line.
+func errorMessage(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []any:
+		messages := make([]string, 0, len(v))
+		for _, item := range v {
+			messages = append(messages, errorMessage(item))
+		}
+		return strings.Join(messages, "; ")
+	case map[string]any:
+		if detail, ok := v["detail"]; ok {
+			return errorMessage(detail)
+		}
+		if message, ok := v["message"]; ok {
+			return errorMessage(message)
+		}
+		messages := make([]string, 0, len(v))
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			messages = append(messages, key+": "+errorMessage(v[key]))
+		}
+		return strings.Join(messages, "; ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func closeBody(resp *http.Response) {
+	if err := resp.Body.Close(); err != nil {
+		slog.Warn("failed to close response body", "error", err)
+	}
+}
@@ -0,0 +1,168 @@
+package coverflex
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+// memoryTokens is a token repository kept in memory.
+type memoryTokens struct {
+	mu     sync.Mutex
+	tokens *domain.TokenPair
+}
+
+func (r *memoryTokens) GetTokens() (*domain.TokenPair, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.tokens == nil {
+		return nil, errors.New("no tokens")
+	}
+	tokens := *r.tokens
+	return &tokens, nil
+}
+
+func (r *memoryTokens) SaveTokens(accessToken, refreshToken string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens = &domain.TokenPair{AccessToken: accessToken, RefreshToken: refreshToken}
+	return nil
+}
+
+func (r *memoryTokens) DeleteTokens() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens = nil
+	return nil
+}
+
+// newServedClient returns a client whose requests to the Coverflex API are served by the handler.
+func newServedClient(t *testing.T, tokens domain.TokenRepository, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewClient(tokens, WithLocale("pt"))
+	transport := server.Client().Transport
+	client.httpClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		req.URL.Scheme, req.URL.Host = "http", strings.TrimPrefix(server.URL, "http://")
+		return transport.RoundTrip(req)
+	})}
+	return client
+}
+
+func TestDoRequestRefreshesTheSessionOnce(t *testing.T) {
+	tokens := &memoryTokens{tokens: &domain.TokenPair{AccessToken: "expired", RefreshToken: "refresh"}}
+	var renewals atomic.Int32
+	client := newServedClient(t, tokens, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/employee/sessions/renew":
+			renewals.Add(1)
+			if r.Header.Get("Authorization") != "Bearer refresh" || r.Header.Get("Accept-Language") != "" {
+				t.Errorf("renewed with %q in %q, want the refresh token and no language", r.Header.Get("Authorization"), r.Header.Get("Accept-Language"))
+			}
+			_, _ = w.Write([]byte(`{"data": {"access_token": "fresh", "refresh_token": "refresh2"}}`))
+		case "/api/employee/cards":
+			if r.Header.Get("Authorization") != "Bearer fresh" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if r.Header.Get("Accept-Language") != "pt" {
+				t.Errorf("cards requested in %q, want pt", r.Header.Get("Accept-Language"))
+			}
+			_, _ = w.Write([]byte(`{"cards": [{"id": "c1", "status": "active"}]}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			cards, err := client.GetCards(t.Context())
+			if err != nil || len(cards) != 1 || cards[0].ID != "c1" {
+				t.Errorf("GetCards() = %+v, %v, want card c1 after the refresh", cards, err)
+			}
+		})
+	}
+	wg.Wait()
+
+	if renewals.Load() != 1 {
+		t.Errorf("session renewed %d times, want once for the concurrent requests", renewals.Load())
+	}
+	if saved, _ := tokens.GetTokens(); saved.AccessToken != "fresh" || saved.RefreshToken != "refresh2" {
+		t.Errorf("saved tokens = %+v, want the renewed ones", saved)
+	}
+}
+
+func TestDoRequestFailedRefreshLogsOut(t *testing.T) {
+	tokens := &memoryTokens{tokens: &domain.TokenPair{AccessToken: "expired", RefreshToken: "revoked"}}
+	client := newServedClient(t, tokens, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	if _, err := client.GetCards(t.Context()); err == nil || !strings.Contains(err.Error(), "token refresh failed") {
+		t.Errorf("GetCards() error = %v, want the refresh failure", err)
+	}
+	if client.IsLoggedIn() {
+		t.Errorf("IsLoggedIn() = true, want the rejected tokens deleted")
+	}
+	if _, err := client.GetCards(t.Context()); err == nil || !strings.Contains(err.Error(), "not logged in") {
+		t.Errorf("GetCards() error = %v, want not logged in", err)
+	}
+}
+
+func TestDoRequestSendsJSONWithoutSession(t *testing.T) {
+	client := newServedClient(t, &memoryTokens{}, func(w http.ResponseWriter, r *http.Request) {
+		var body sessionRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Email != "ana@example.com" || body.OTP != "" {
+			t.Errorf("session request body = %+v, %v, want the email and no OTP", body, err)
+		}
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" || r.Header.Get("Authorization") != "" {
+			t.Errorf("session request %s with headers %v, want an unauthenticated JSON POST", r.Method, r.Header)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"phone_last_digits": "42"}`))
+	})
+	if err := client.RequestOTP(t.Context(), "ana@example.com", "secret"); err != nil {
+		t.Errorf("RequestOTP() error = %v", err)
+	}
+}
+
+func TestDoRequestAPIErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		message string
+	}{
+		{"message", http.StatusBadRequest, `{"message": "Invalid page"}`, "Invalid page"},
+		{"error detail", http.StatusForbidden, `{"error": {"detail": "Not allowed"}}`, "Not allowed"},
+		{"field errors", http.StatusUnprocessableEntity, `{"errors": {"password": ["is too short"], "email": ["is invalid", "is taken"]}}`,
+			"email: is invalid; is taken; password: is too short"},
+		{"not JSON", http.StatusBadGateway, `<html>Bad gateway</html>`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newServedClient(t, &memoryTokens{tokens: &domain.TokenPair{AccessToken: "access"}}, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			})
+			_, err := client.GetCards(t.Context())
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || !IsStatus(err, tt.status) {
+				t.Fatalf("GetCards() error = %v, want an APIError with status %d", err, tt.status)
+			}
+			if apiErr.Message != tt.message || apiErr.Body != tt.body {
+				t.Errorf("APIError message %q and body %q, want %q and the raw body", apiErr.Message, apiErr.Body, tt.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package coverflex
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+// timestampDTO is an instant as the API sends it, an ISO-8601 string such as "2025-03-08T12:30:15Z", or null.
+type timestampDTO struct {
+	value domain.Timestamp
+}
+
+func (timestampDTO) wireKind() string { return "string" }
+
+func (t *timestampDTO) UnmarshalJSON(data []byte) error {
+	value, err := decodeWireTime(data)
+	if err != nil || value == "" {
+		*t = timestampDTO{}
+		return err
+	}
+	parsed, err := domain.ParseTimestamp(value)
+	if err != nil {
+		return err
+	}
+	t.value = parsed
+	return nil
+}
+
+// dateDTO is a calendar day as the API sends it, a "2006-01-02" string, or null.
+type dateDTO struct {
+	value domain.Date
+}
+
+func (dateDTO) wireKind() string { return "string" }
+
+func (d *dateDTO) UnmarshalJSON(data []byte) error {
+	value, err := decodeWireTime(data)
+	if err != nil || value == "" {
+		*d = dateDTO{}
+		return err
+	}
+	parsed, err := domain.ParseDate(value)
+	if err != nil {
+		return err
+	}
+	d.value = parsed
+	return nil
+}
+
+// decodeWireTime returns the trimmed string of a JSON string, or an empty string for null.
+func decodeWireTime(data []byte) (string, error) {
+	var value *string
+	if err := json.Unmarshal(data, &value); err != nil || value == nil {
+		return "", err
+	}
+	return strings.TrimSpace(*value), nil
+}
+
+func toDomainTimestamp(dto timestampDTO) domain.Timestamp {
+	return dto.value
+}
+
+// toDomainOptionalTimestamp maps an instant the API may leave out, such as the activation of a new card.
+func toDomainOptionalTimestamp(dto *timestampDTO) *domain.Timestamp {
+	if dto == nil || dto.value.IsZero() {
+		return nil
+	}
+	timestamp := toDomainTimestamp(*dto)
+	return &timestamp
+}
+
+func toDomainDate(dto dateDTO) domain.Date {
+	return dto.value
+}
@@ -0,0 +1,145 @@
+package coverflex
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/tembleking/coverflex-mcp/internal/version"
+)
+
+// Default transport settings used when no ClientOption overrides them.
+const (
+	DefaultTimeout               = 30 * time.Second
+	DefaultDialTimeout           = 10 * time.Second
+	DefaultTLSHandshakeTimeout   = 10 * time.Second
+	DefaultResponseHeaderTimeout = 20 * time.Second
+)
+
+// DefaultUserAgent returns the User-Agent sent to the Coverflex API, identifying coverflex-mcp and its version.
+func DefaultUserAgent() string {
+	return "coverflex-mcp/" + version.String() + " (+https://github.com/tembleking/coverflex-mcp)"
+}
+
+// clientConfig holds the settings applied by NewClient.
+type clientConfig struct {
+	timeout               time.Duration
+	dialTimeout           time.Duration
+	tlsHandshakeTimeout   time.Duration
+	responseHeaderTimeout time.Duration
+	proxyURL              *url.URL
+	rootCAs               *x509.CertPool
+	userAgent             string
+	strictDecoding        bool
+	locale                string
+}
+
+// ClientOption defines a function that modifies the Client configuration.
+type ClientOption func(*clientConfig)
+
+// WithTimeout sets the overall time limit for a request, including reading the response body.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(cfg *clientConfig) {
+		if timeout > 0 {
+			cfg.timeout = timeout
+		}
+	}
+}
+
+// WithDialTimeout sets the time limit for establishing the TCP connection.
+func WithDialTimeout(timeout time.Duration) ClientOption {
+	return func(cfg *clientConfig) {
+		if timeout > 0 {
+			cfg.dialTimeout = timeout
+		}
+	}
+}
+
+// WithTLSHandshakeTimeout sets the time limit for the TLS handshake.
+func WithTLSHandshakeTimeout(timeout time.Duration) ClientOption {
+	return func(cfg *clientConfig) {
+		if timeout > 0 {
+			cfg.tlsHandshakeTimeout = timeout
+		}
+	}
+}
+
+// WithResponseHeaderTimeout sets the time limit for waiting for the response headers once the request is sent.
+func WithResponseHeaderTimeout(timeout time.Duration) ClientOption {
+	return func(cfg *clientConfig) {
+		if timeout > 0 {
+			cfg.responseHeaderTimeout = timeout
+		}
+	}
+}
+
+// WithProxy routes every request through the given HTTP(S) proxy.
+// Without this option, the proxy is taken from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+func WithProxy(proxyURL *url.URL) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.proxyURL = proxyURL
+	}
+}
+
+// WithRootCAs sets the certificate pool used to verify the Coverflex API certificates.
+// This is useful behind TLS-intercepting proxies, whose CA must be trusted explicitly.
+func WithRootCAs(pool *x509.CertPool) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.rootCAs = pool
+	}
+}
+
+// WithUserAgent overrides the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(cfg *clientConfig) {
+		if userAgent != "" {
+			cfg.userAgent = userAgent
+		}
+	}
+}
+
+// newHTTPClient builds the HTTP client used to talk to the Coverflex API from the given configuration.
+func newHTTPClient(cfg *clientConfig) *http.Client {
+	proxy := http.ProxyFromEnvironment
+	if cfg.proxyURL != nil {
+		proxy = http.ProxyURL(cfg.proxyURL)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxy
+	transport.DialContext = (&net.Dialer{
+		Timeout:   cfg.dialTimeout,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	transport.TLSHandshakeTimeout = cfg.tlsHandshakeTimeout
+	transport.ResponseHeaderTimeout = cfg.responseHeaderTimeout
+	if cfg.rootCAs != nil {
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    cfg.rootCAs,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	return &http.Client{
+		Timeout: cfg.timeout,
+		Transport: &userAgentTransport{
+			userAgent: cfg.userAgent,
+			next:      transport,
+		},
+	}
+}
+
+// userAgentTransport sets the User-Agent header on every outgoing request.
+type userAgentTransport struct {
+	userAgent string
+	next      http.RoundTripper
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.next.RoundTrip(req)
+}
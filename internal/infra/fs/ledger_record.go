@@ -0,0 +1,188 @@
+package fs
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+// ledgerFileVersion is the version of the ledger file format, bumped on incompatible changes.
+const ledgerFileVersion = 1
+
+// The records below are the ledger file format. They are mapped to the domain explicitly, so a change
+// in the domain entities does not silently change the files already stored.
+
+// ledgerFile is the content of the ledger file.
+type ledgerFile struct {
+	Version     int                        `json:"version"`
+	LastSyncAt  timeRecord                 `json:"last_sync_at"`
+	Complete    bool                       `json:"complete"`
+	Operations  map[string]operationRecord `json:"operations"`
+	Transitions []transitionRecord         `json:"transitions"`
+}
+
+// timeRecord is an instant stored as RFC 3339.
+type timeRecord struct {
+	time.Time
+}
+
+func (r timeRecord) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.Format(time.RFC3339Nano))
+}
+
+func (r *timeRecord) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return err
+	}
+	r.Time = t
+	return nil
+}
+
+// moneyRecord is an amount in the minor units of its currency.
+type moneyRecord struct {
+	MinorUnits int64  `json:"minor_units"`
+	Currency   string `json:"currency"`
+}
+
+type descriptionParamRecord struct {
+	Key   string `json:"key"`
+	Value any    `json:"value"`
+}
+
+// operationRecord is an operation as synced, without the description and refund links derived when read.
+type operationRecord struct {
+	ID                string                   `json:"id"`
+	Amount            moneyRecord              `json:"amount"`
+	DescriptionTag    string                   `json:"description_tag"`
+	DescriptionParams []descriptionParamRecord `json:"description_params"`
+	CategorySlug      string                   `json:"category_slug"`
+	ProductSlug       string                   `json:"product_slug"`
+	ExecutedAt        timeRecord               `json:"executed_at"`
+	IsDebit           bool                     `json:"is_debit"`
+	MerchantName      string                   `json:"merchant_name,omitempty"`
+	Status            string                   `json:"status"`
+	Type              string                   `json:"type"`
+}
+
+type transitionRecord struct {
+	OperationID    string       `json:"operation_id"`
+	From           string       `json:"from"`
+	To             string       `json:"to"`
+	PreviousAmount *moneyRecord `json:"previous_amount,omitempty"`
+	Reversal       bool         `json:"reversal"`
+	DetectedAt     timeRecord   `json:"detected_at"`
+}
+
+func toLedgerFile(ledger *domain.Ledger) ledgerFile {
+	file := ledgerFile{
+		Version:     ledgerFileVersion,
+		LastSyncAt:  timeRecord{ledger.LastSyncAt.Time},
+		Complete:    ledger.Complete,
+		Operations:  make(map[string]operationRecord, len(ledger.Operations)),
+		Transitions: make([]transitionRecord, 0, len(ledger.Transitions)),
+	}
+	for id, operation := range ledger.Operations {
+		file.Operations[id] = toOperationRecord(operation)
+	}
+	for _, transition := range ledger.Transitions {
+		file.Transitions = append(file.Transitions, toTransitionRecord(transition))
+	}
+	return file
+}
+
+func toDomainLedger(file ledgerFile) *domain.Ledger {
+	ledger := domain.NewLedger()
+	ledger.LastSyncAt = domain.NewTimestamp(file.LastSyncAt.Time)
+	ledger.Complete = file.Complete
+	for id, record := range file.Operations {
+		ledger.Operations[id] = toDomainOperation(record)
+	}
+	for _, record := range file.Transitions {
+		ledger.Transitions = append(ledger.Transitions, toDomainTransition(record))
+	}
+	return ledger
+}
+
+func toMoneyRecord(money domain.Money) moneyRecord {
+	return moneyRecord{MinorUnits: money.MinorUnits, Currency: money.Currency}
+}
+
+func toDomainMoney(r moneyRecord) domain.Money {
+	return domain.NewMoney(r.MinorUnits, r.Currency)
+}
+
+func toOperationRecord(operation domain.Operation) operationRecord {
+	record := operationRecord{
+		ID:                operation.ID,
+		Amount:            toMoneyRecord(operation.Amount),
+		DescriptionTag:    operation.DescriptionTag,
+		DescriptionParams: make([]descriptionParamRecord, 0, len(operation.DescriptionParams)),
+		CategorySlug:      string(operation.CategorySlug),
+		ProductSlug:       operation.ProductSlug,
+		ExecutedAt:        timeRecord{operation.ExecutedAt.Time},
+		IsDebit:           operation.IsDebit,
+		MerchantName:      operation.MerchantName,
+		Status:            string(operation.Status),
+		Type:              string(operation.Type),
+	}
+	for _, param := range operation.DescriptionParams {
+		record.DescriptionParams = append(record.DescriptionParams, descriptionParamRecord{Key: param.Key, Value: param.Value})
+	}
+	return record
+}
+
+func toDomainOperation(r operationRecord) domain.Operation {
+	operation := domain.Operation{
+		ID:                r.ID,
+		Amount:            toDomainMoney(r.Amount),
+		DescriptionTag:    r.DescriptionTag,
+		DescriptionParams: make([]domain.DescriptionParam, 0, len(r.DescriptionParams)),
+		CategorySlug:      domain.CategorySlug(r.CategorySlug),
+		ProductSlug:       r.ProductSlug,
+		ExecutedAt:        domain.NewTimestamp(r.ExecutedAt.Time),
+		IsDebit:           r.IsDebit,
+		MerchantName:      r.MerchantName,
+		Status:            domain.OperationStatus(r.Status),
+		Type:              domain.OperationType(r.Type),
+	}
+	for _, param := range r.DescriptionParams {
+		operation.DescriptionParams = append(operation.DescriptionParams, domain.DescriptionParam{Key: param.Key, Value: param.Value})
+	}
+	return operation
+}
+
+func toTransitionRecord(transition domain.StatusTransition) transitionRecord {
+	record := transitionRecord{
+		OperationID: transition.OperationID,
+		From:        string(transition.From),
+		To:          string(transition.To),
+		Reversal:    transition.Reversal,
+		DetectedAt:  timeRecord{transition.DetectedAt.Time},
+	}
+	if transition.PreviousAmount != nil {
+		amount := toMoneyRecord(*transition.PreviousAmount)
+		record.PreviousAmount = &amount
+	}
+	return record
+}
+
+func toDomainTransition(r transitionRecord) domain.StatusTransition {
+	transition := domain.StatusTransition{
+		OperationID: r.OperationID,
+		From:        domain.OperationStatus(r.From),
+		To:          domain.OperationStatus(r.To),
+		Reversal:    r.Reversal,
+		DetectedAt:  domain.NewTimestamp(r.DetectedAt.Time),
+	}
+	if r.PreviousAmount != nil {
+		amount := toDomainMoney(*r.PreviousAmount)
+		transition.PreviousAmount = &amount
+	}
+	return transition
+}
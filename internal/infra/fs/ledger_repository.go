@@ -0,0 +1,91 @@
+package fs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+const ledgerFileName = "ledger.json"
+
+// LedgerRepository persists the operations ledger as a JSON file.
+type LedgerRepository struct {
+	path string
+}
+
+// NewLedgerRepository creates a ledger repository stored at path, or in the user cache directory if empty.
+func NewLedgerRepository(path string) *LedgerRepository {
+	if path == "" {
+		path = DefaultLedgerPath()
+	}
+	return &LedgerRepository{path: path}
+}
+
+// DefaultLedgerPath returns the ledger location in the user cache directory, or in the temporary directory
+// if there is none.
+func DefaultLedgerPath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "coverflex-mcp", ledgerFileName)
+}
+
+// Path returns the location of the ledger file.
+func (r *LedgerRepository) Path() string {
+	return r.path
+}
+
+// Load reads the ledger from the filesystem, returning an empty ledger if the file does not exist.
+func (r *LedgerRepository) Load() (*domain.Ledger, error) {
+	data, err := os.ReadFile(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return domain.NewLedger(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read ledger file: %w", err)
+	}
+
+	var file ledgerFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("could not decode ledger file %s: %w", r.path, err)
+	}
+	if file.Version != ledgerFileVersion {
+		return nil, fmt.Errorf("unsupported ledger version %d in %s, remove it and sync again", file.Version, r.path)
+	}
+	return toDomainLedger(file), nil
+}
+
+// Save writes the ledger to the filesystem, replacing the previous file atomically.
+func (r *LedgerRepository) Save(ledger *domain.Ledger) error {
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o700); err != nil {
+		return fmt.Errorf("could not create ledger directory: %w", err)
+	}
+	data, err := json.Marshal(toLedgerFile(ledger))
+	if err != nil {
+		return fmt.Errorf("could not encode ledger: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(r.path), ledgerFileName+".*")
+	if err != nil {
+		return fmt.Errorf("could not create ledger file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("could not write ledger file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("could not write ledger file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), r.path); err != nil {
+		return fmt.Errorf("could not replace ledger file: %w", err)
+	}
+	slog.Info("Ledger saved", "path", r.path, "operations", len(ledger.Operations))
+	return nil
+}
@@ -0,0 +1,92 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+func TestLedgerRepositoryRoundTrip(t *testing.T) {
+	repo := NewLedgerRepository(filepath.Join(t.TempDir(), "cache", ledgerFileName))
+	empty, err := repo.Load()
+	if err != nil || len(empty.Operations) != 0 || empty.Complete {
+		t.Fatalf("Load() of a missing file = %+v, %v, want an empty ledger", empty, err)
+	}
+
+	executedAt := domain.NewTimestamp(time.Date(2025, time.March, 8, 12, 30, 15, 500, time.UTC))
+	syncedAt := domain.NewTimestamp(time.Date(2025, time.March, 9, 8, 0, 0, 0, time.UTC))
+	previous := domain.NewMoney(-1250, domain.DefaultCurrency)
+	ledger := domain.NewLedger()
+	ledger.LastSyncAt, ledger.Complete = syncedAt, true
+	ledger.Operations["op1"] = domain.Operation{
+		ID:                "op1",
+		Amount:            domain.NewMoney(-1000, domain.DefaultCurrency),
+		DescriptionTag:    "card_transaction",
+		DescriptionParams: []domain.DescriptionParam{{Key: "merchant_name", Value: "Pingo Doce"}},
+		CategorySlug:      domain.CategoryMeal,
+		ProductSlug:       "meal",
+		ExecutedAt:        executedAt,
+		IsDebit:           true,
+		MerchantName:      "Pingo Doce",
+		Status:            domain.OperationStatusConfirmed,
+		Type:              domain.OperationTypeCardTransaction,
+	}
+	ledger.Transitions = append(ledger.Transitions, domain.StatusTransition{
+		OperationID:    "op1",
+		From:           domain.OperationStatusPending,
+		To:             domain.OperationStatusConfirmed,
+		PreviousAmount: &previous,
+		DetectedAt:     syncedAt,
+	})
+	if err := repo.Save(ledger); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := repo.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !loaded.LastSyncAt.Equal(syncedAt.Time) || !loaded.Complete {
+		t.Errorf("loaded ledger synced at %s, complete %v, want %s and complete", loaded.LastSyncAt, loaded.Complete, syncedAt)
+	}
+	operation := loaded.Operations["op1"]
+	if !operation.ExecutedAt.Equal(executedAt.Time) {
+		t.Errorf("executed at %s, want %s", operation.ExecutedAt, executedAt)
+	}
+	operation.ExecutedAt = executedAt
+	if want := ledger.Operations["op1"]; !reflect.DeepEqual(operation, want) {
+		t.Errorf("operation = %+v, want %+v", operation, want)
+	}
+	if len(loaded.Transitions) != 1 || *loaded.Transitions[0].PreviousAmount != previous || !loaded.Transitions[0].DetectedAt.Equal(syncedAt.Time) {
+		t.Errorf("transitions = %+v, want the pending operation confirmed", loaded.Transitions)
+	}
+}
+
+func TestLedgerRepositoryLoadRejectsOtherVersions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ledgerFileName)
+	if err := os.WriteFile(path, []byte(`{"version":2,"last_sync_at":"2025-03-09T08:00:00Z","operations":{}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewLedgerRepository(path).Load(); err == nil || !strings.Contains(err.Error(), "unsupported ledger version 2") {
+		t.Errorf("Load() error = %v, want an unsupported version", err)
+	}
+}
+
+func TestTimeRecordIsRFC3339(t *testing.T) {
+	var record timeRecord
+	if err := record.UnmarshalJSON([]byte(`"2025-03-08T12:30:15.0000005+01:00"`)); err != nil {
+		t.Fatalf("UnmarshalJSON() error = %v", err)
+	}
+	if want := time.Date(2025, time.March, 8, 11, 30, 15, 500, time.UTC); !record.Equal(want) {
+		t.Errorf("time = %s, want %s", record.Time, want)
+	}
+	// The time as the API sends it is not a ledger record.
+	if err := record.UnmarshalJSON([]byte(`{"iso":"2025-03-08T12:30:15Z"}`)); err == nil {
+		t.Errorf("UnmarshalJSON() of an object succeeded, want an error")
+	}
+}
@@ -0,0 +1,20 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+// withLanguageArgument declares the optional 'language' argument of the read tools.
+func withLanguageArgument() mcp.ToolOption {
+	return mcp.WithString("language",
+		mcp.Description("Language of the returned content, as an ISO 639-1 code or locale (e.g. 'pt', 'es', 'en', 'pt-PT'). Defaults to the configured locale or the first language of the company market."),
+	)
+}
+
+// withRequestLanguage returns a context that sends the requests in the language chosen in the tool call, if any.
+func withRequestLanguage(ctx context.Context, request mcp.CallToolRequest) context.Context {
+	return domain.WithLanguage(ctx, request.GetString("language", ""))
+}
@@ -23,6 +23,8 @@ func NewHandler() *Handler {
 		"1.0.0",
 		server.WithInstructions(`You are a helpful assistant for managing Coverflex benefits. You have access to a set of tools to retrieve information about the user's benefits, cards, company details, and more.
 
+Monetary amounts are returned as objects whose 'minor_units' are in the smallest unit of the currency (e.g. 1234 EUR minor units are 12,34 €). Always use their 'decimal' or 'formatted' values when reporting amounts to the user.
+
 If no tools are available, it means the user is not logged in. To help the user, follow these steps:
 1. First, check if the 'COVERFLEX_USERNAME' and 'COVERFLEX_PASSWORD' environment variables are set.
 2. If the environment variables are set but the user is not logged in, the device may not be trusted. In this case, suggest using the 'trust_device' tool with the OTP.
@@ -0,0 +1,155 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+// multiValueHint explains the syntax of the filters that accept several values.
+const multiValueHint = "Accepts several comma separated values, and values prefixed with '!' are excluded, e.g. 'a,b' or '!c'."
+
+// withEnumFilterArguments declares the arguments of the values to keep and to leave out of an enum field,
+// as arrays whose items are limited to the known values.
+func withEnumFilterArguments(include, exclude, what string, values []string) mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithArray(include,
+			mcp.Description(fmt.Sprintf("The %s to keep. Keeps them all when empty.", what)),
+			mcp.WithStringEnumItems(values),
+		)(tool)
+		mcp.WithArray(exclude,
+			mcp.Description(fmt.Sprintf("The %s to leave out.", what)),
+			mcp.WithStringEnumItems(values),
+		)(tool)
+	}
+}
+
+// requestEnumFilter returns the values kept and left out by the arguments declared by withEnumFilterArguments.
+func requestEnumFilter[T ~string](request mcp.CallToolRequest, include, exclude string) domain.ValueFilter[T] {
+	values := func(name string) []T {
+		var values []T
+		for _, value := range request.GetStringSlice(name, nil) {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, T(value))
+			}
+		}
+		return values
+	}
+	return domain.ValueFilter[T]{Include: values(include), Exclude: values(exclude)}
+}
+
+// withOperationFilterArguments declares the filter arguments of the operations tools.
+func withOperationFilterArguments() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		for _, option := range []mcp.ToolOption{
+			withEnumFilterArguments("filter_type", "exclude_type", "operation types", domain.EnumStrings(domain.KnownOperationTypes)),
+			withEnumFilterArguments("category", "exclude_category", "benefit categories", domain.EnumStrings(domain.KnownCategorySlugs)),
+			mcp.WithString("product",
+				mcp.Description("The product slugs to keep. "+multiValueHint),
+			),
+			withEnumFilterArguments("status", "exclude_status", "operation statuses", domain.EnumStrings(domain.KnownOperationStatuses)),
+			mcp.WithString("merchant",
+				mcp.Description("Keep the operations whose merchant name contains one of the values, ignoring case and accents. "+multiValueHint),
+			),
+			mcp.WithBoolean("is_debit",
+				mcp.Description("Keep only debits (true) or only credits (false)."),
+			),
+			mcp.WithNumber("min_amount",
+				mcp.Description("Keep the operations of at least this amount, in euros and regardless of its sign, e.g. 12.5."),
+				mcp.Min(0),
+			),
+			mcp.WithNumber("max_amount",
+				mcp.Description("Keep the operations of at most this amount, in euros and regardless of its sign, e.g. 100."),
+				mcp.Min(0),
+			),
+			withPeriodArguments(),
+		} {
+			option(tool)
+		}
+	}
+}
+
+// withPeriodArguments declares the 'from', 'to' and 'range' arguments of the operations tools.
+func withPeriodArguments() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("from",
+			mcp.Description("Only return operations executed on or after this day, as 'YYYY-MM-DD', or a month as 'YYYY-MM' meaning its first day."),
+		)(tool)
+		mcp.WithString("to",
+			mcp.Description("Only return operations executed on or before this day, as 'YYYY-MM-DD', or a month as 'YYYY-MM' meaning its last day."),
+		)(tool)
+		mcp.WithString("range",
+			mcp.Description("A relative period to return the operations of, instead of 'from' and 'to'. The benefit periods are yearly and end the day before the benefits renew."),
+			mcp.Enum(domain.RelativeRanges...),
+		)(tool)
+	}
+}
+
+// requestFilters returns the operation filters chosen in the tool call.
+func requestFilters(ctx context.Context, request mcp.CallToolRequest, compensation domain.CompensationReader) (domain.OperationsFilters, error) {
+	filters := domain.OperationsFilters{
+		Types:      requestEnumFilter[domain.OperationType](request, "filter_type", "exclude_type"),
+		Categories: requestEnumFilter[domain.CategorySlug](request, "category", "exclude_category"),
+		Products:   domain.ParseValueFilter[string](request.GetString("product", "")),
+		Statuses:   requestEnumFilter[domain.OperationStatus](request, "status", "exclude_status"),
+		Merchants:  domain.ParseValueFilter[string](request.GetString("merchant", "")),
+	}
+	if isDebit, err := request.RequireBool("is_debit"); err == nil {
+		filters.IsDebit = &isDebit
+	}
+	if minAmount, err := request.RequireFloat("min_amount"); err == nil {
+		amount := domain.MoneyFromMajor(minAmount, domain.DefaultCurrency)
+		filters.Amount.Min = &amount
+	}
+	if maxAmount, err := request.RequireFloat("max_amount"); err == nil {
+		amount := domain.MoneyFromMajor(maxAmount, domain.DefaultCurrency)
+		filters.Amount.Max = &amount
+	}
+	if err := filters.Amount.Validate(); err != nil {
+		return domain.OperationsFilters{}, err
+	}
+
+	period, err := requestPeriod(ctx, request, compensation)
+	if err != nil {
+		return domain.OperationsFilters{}, err
+	}
+	filters.Period = period
+	return filters, nil
+}
+
+// requestPeriod returns the period chosen in the tool call, if any. The compensation is only retrieved
+// to resolve the benefit period ranges.
+func requestPeriod(ctx context.Context, request mcp.CallToolRequest, compensation domain.CompensationReader) (domain.DateRange, error) {
+	from := request.GetString("from", "")
+	to := request.GetString("to", "")
+	name := request.GetString("range", "")
+	if name == "" {
+		return domain.ParseDateRange(from, to)
+	}
+	if from != "" || to != "" {
+		return domain.DateRange{}, fmt.Errorf("'range' cannot be combined with 'from' or 'to'")
+	}
+
+	var renewal domain.Date
+	if domain.IsBenefitPeriodRange(name) {
+		summary, err := compensation.GetCompensation(ctx)
+		if err != nil {
+			return domain.DateRange{}, fmt.Errorf("error getting the benefit renewal date: %w", err)
+		}
+		renewal = summary.RenewalDate
+	}
+	return domain.ResolveRelativeRange(name, domain.Today(), renewal)
+}
+
+// requestPeriodOr is requestPeriod resolving the relative range named fallback, which cannot be a benefit
+// period, when the call sets no period.
+func requestPeriodOr(ctx context.Context, request mcp.CallToolRequest, compensation domain.CompensationReader, fallback string) (domain.DateRange, error) {
+	period, err := requestPeriod(ctx, request, compensation)
+	if err != nil || !period.IsZero() || request.GetString("range", "") != "" {
+		return period, err
+	}
+	return domain.ResolveRelativeRange(fallback, domain.Today(), domain.Date{})
+}
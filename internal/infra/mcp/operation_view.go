@@ -0,0 +1,16 @@
+package mcp
+
+import (
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+	"github.com/tembleking/coverflex-mcp/internal/i18n"
+)
+
+// describeOperations renders the description of every operation in the given locale.
+func describeOperations(renderer *i18n.DescriptionRenderer, locale string, operations []domain.Operation) []domain.Operation {
+	described := make([]domain.Operation, 0, len(operations))
+	for _, operation := range operations {
+		operation.Description = renderer.Render(locale, operation.DescriptionTag, operation.Params())
+		described = append(described, operation)
+	}
+	return described
+}
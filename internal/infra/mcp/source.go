@@ -0,0 +1,22 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/tembleking/coverflex-mcp/internal/ledger"
+)
+
+// withSourceArgument declares the optional 'source' argument of the operations tools.
+func withSourceArgument() mcp.ToolOption {
+	return mcp.WithString("source",
+		mcp.Description("Where to read the operations from: 'api' fetches them from Coverflex, 'ledger' reads the local copy kept by the 'sync_operations' tool, which is faster but only as recent as the last sync."),
+		mcp.Enum(ledger.Sources...),
+		mcp.DefaultString(ledger.SourceAPI),
+	)
+}
+
+// withRequestSource returns a context that reads the operations from the source chosen in the tool call, if any.
+func withRequestSource(ctx context.Context, request mcp.CallToolRequest) context.Context {
+	return ledger.WithSource(ctx, request.GetString("source", ""))
+}
@@ -0,0 +1,109 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+	"github.com/tembleking/coverflex-mcp/internal/i18n"
+)
+
+const (
+	getAllOperationsPerPage         = 50
+	getAllOperationsDefaultMaxItems = 500
+	getAllOperationsMaxItems        = 5000
+	getAllOperationsDefaultMaxPages = 20
+	getAllOperationsMaxPages        = 100
+)
+
+type ToolGetAllOperations struct {
+	client   domain.OperationsPeriodIterator
+	renderer *i18n.DescriptionRenderer
+}
+
+// allOperationsResult is the operations fetched by walking the pages, and whether a cap stopped the walk.
+type allOperationsResult struct {
+	Result []domain.Operation `json:"result"`
+	// Truncated reports that max_items or max_pages was reached, so there may be older operations.
+	Truncated bool `json:"truncated"`
+	// Error is set when a page failed to load; the operations fetched before it are still returned.
+	Error string `json:"error,omitempty"`
+}
+
+func NewToolGetAllOperations(client domain.OperationsPeriodIterator, renderer *i18n.DescriptionRenderer) *ToolGetAllOperations {
+	return &ToolGetAllOperations{
+		client:   client,
+		renderer: renderer,
+	}
+}
+
+func (t *ToolGetAllOperations) handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx = withRequestSource(withRequestLanguage(ctx, request), request)
+	maxItems := min(max(request.GetInt("max_items", getAllOperationsDefaultMaxItems), 1), getAllOperationsMaxItems)
+	maxPages := min(max(request.GetInt("max_pages", getAllOperationsDefaultMaxPages), 1), getAllOperationsMaxPages)
+	filters, err := requestFilters(ctx, request, t.client)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(i18n.T(t.client.Locale(ctx), i18n.ErrInvalidFilter), err), nil
+	}
+
+	opts := []domain.GetOperationsOption{
+		domain.WithOperationsPerPage(getAllOperationsPerPage),
+		domain.WithOperationsMaxPages(maxPages),
+		domain.WithOperationsFilters(filters),
+	}
+
+	result := allOperationsResult{Result: []domain.Operation{}}
+	for operation, err := range t.client.AllOperations(ctx, opts...) {
+		if errors.Is(err, domain.ErrPageLimitReached) {
+			result.Truncated = true
+			break
+		}
+		if err != nil {
+			if len(result.Result) == 0 {
+				return mcp.NewToolResultErrorFromErr(i18n.T(t.client.Locale(ctx), i18n.ErrGetOperations), err), nil
+			}
+			slog.Warn("Returning the operations fetched before the error", "count", len(result.Result), "error", err)
+			result.Error = err.Error()
+			break
+		}
+		if len(result.Result) == maxItems {
+			result.Truncated = true
+			break
+		}
+		result.Result = append(result.Result, operation)
+	}
+
+	result.Result = describeOperations(t.renderer, t.client.Locale(ctx), domain.LinkRefunds(result.Result))
+	return mcp.NewToolResultJSON(result)
+}
+
+func (t *ToolGetAllOperations) RegisterInServer(s *server.MCPServer) {
+	tool := mcp.NewTool("get_all_operations",
+		mcp.WithDescription("Retrieve every Coverflex user operation, most recent first, walking all the pages in a single call. The walk stops at max_items operations or max_pages pages of 50 operations, whichever comes first, and 'truncated' reports whether older operations may have been left out. Each operation includes a human-readable 'description'."),
+		mcp.WithNumber("max_items",
+			mcp.Description("The maximum number of operations to return."),
+			mcp.DefaultNumber(getAllOperationsDefaultMaxItems),
+			mcp.Min(1),
+			mcp.Max(getAllOperationsMaxItems),
+		),
+		mcp.WithNumber("max_pages",
+			mcp.Description("The maximum number of pages of 50 operations to fetch."),
+			mcp.DefaultNumber(getAllOperationsDefaultMaxPages),
+			mcp.Min(1),
+			mcp.Max(getAllOperationsMaxPages),
+		),
+		withOperationFilterArguments(),
+		withSourceArgument(),
+		withLanguageArgument(),
+		mcp.WithOutputSchema[allOperationsResult](),
+	)
+
+	s.AddTool(tool, t.handle)
+}
+
+func (t *ToolGetAllOperations) CanBeUsed() bool {
+	return t.client != nil && t.client.IsLoggedIn()
+}
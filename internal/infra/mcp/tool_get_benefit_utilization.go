@@ -0,0 +1,91 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+	"github.com/tembleking/coverflex-mcp/internal/i18n"
+)
+
+const (
+	getBenefitUtilizationDefaultMaxPages = 20
+	getBenefitUtilizationMaxPages        = 100
+)
+
+type ToolGetBenefitUtilization struct {
+	client domain.BenefitUtilizationReader
+}
+
+// benefitUtilizationResult is the utilization of every benefit, and whether the page limit left operations out.
+type benefitUtilizationResult struct {
+	Today    domain.Date                 `json:"today"`
+	Benefits []domain.BenefitUtilization `json:"benefits"`
+	// Incomplete reports that max_pages was reached, so older operations were left out of the amounts used.
+	Incomplete bool `json:"incomplete"`
+	// Warnings explain what the utilization had to assume, such as the benefit year when the renewal date is unknown.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+func NewToolGetBenefitUtilization(client domain.BenefitUtilizationReader) *ToolGetBenefitUtilization {
+	return &ToolGetBenefitUtilization{client: client}
+}
+
+func (t *ToolGetBenefitUtilization) handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx = withRequestSource(withRequestLanguage(ctx, request), request)
+	locale := t.client.Locale(ctx)
+	maxPages := min(max(request.GetInt("max_pages", getBenefitUtilizationDefaultMaxPages), 1), getBenefitUtilizationMaxPages)
+
+	benefits, err := t.client.GetBenefits(ctx)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(i18n.T(locale, i18n.ErrGetBenefits), err), nil
+	}
+	// Without the renewal date, the benefit year falls back to the calendar year.
+	var renewal domain.Date
+	var warnings []string
+	if compensation, err := t.client.GetCompensation(ctx); err != nil {
+		warnings = append(warnings, i18n.T(locale, i18n.UnknownRenewal, err))
+	} else {
+		renewal = compensation.RenewalDate
+	}
+
+	today := domain.Today()
+	month, year := domain.UtilizationRanges(today, renewal)
+	// The benefit year can start after the first day of the month.
+	period := domain.DateRange{From: year.From, To: today}
+	if month.From.Before(period.From) {
+		period.From = month.From
+	}
+	operations, incomplete, err := domain.LoadSpendingOperations(ctx, t.client, period, maxPages)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(i18n.T(locale, i18n.ErrGetOperations), err), nil
+	}
+	utilization, err := domain.SummarizeBenefitUtilization(benefits, operations, today, renewal)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(i18n.T(locale, i18n.ErrGetOperations), err), nil
+	}
+	return mcp.NewToolResultJSON(benefitUtilizationResult{Today: today, Benefits: utilization, Incomplete: incomplete, Warnings: warnings})
+}
+
+func (t *ToolGetBenefitUtilization) RegisterInServer(s *server.MCPServer) {
+	tool := mcp.NewTool("get_benefit_utilization",
+		mcp.WithDescription(fmt.Sprintf("Compare the spending of each Coverflex benefit with its monthly and yearly limits, such as the tax-exempt caps: the amount used in the current month and benefit year, what remains and the percentage used, and the amount each period is projected to end with at the current daily pace. 'at_risk' flags the benefits whose limits are exceeded, or projected to be exceeded once at least %d days and %d payments of the period give the projection enough history. Amounts are net of refunds and leave out rollovers and reversed or failed payments. When the renewal date cannot be fetched, the benefit year is the calendar year and 'warnings' says so.", domain.ProjectionMinDays, domain.ProjectionMinPayments)),
+		mcp.WithNumber("max_pages",
+			mcp.Description("The maximum number of pages of 50 operations to walk."),
+			mcp.DefaultNumber(getBenefitUtilizationDefaultMaxPages),
+			mcp.Min(1),
+			mcp.Max(getBenefitUtilizationMaxPages),
+		),
+		withSourceArgument(),
+		withLanguageArgument(),
+		mcp.WithOutputSchema[benefitUtilizationResult](),
+	)
+
+	s.AddTool(tool, t.handle)
+}
+
+func (t *ToolGetBenefitUtilization) CanBeUsed() bool {
+	return t.client != nil && t.client.IsLoggedIn()
+}
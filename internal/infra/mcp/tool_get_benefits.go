@@ -5,37 +5,40 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
-	"github.com/tembleking/coverflex-mcp/internal/infra/coverflex"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+	"github.com/tembleking/coverflex-mcp/internal/i18n"
 )
 
 type ToolGetBenefits struct {
-	coverflexClient *coverflex.Client
+	client domain.BenefitsReader
 }
 
-func NewToolGetBenefits(client *coverflex.Client) *ToolGetBenefits {
+func NewToolGetBenefits(client domain.BenefitsReader) *ToolGetBenefits {
 	return &ToolGetBenefits{
-		coverflexClient: client,
+		client: client,
 	}
 }
 
 func (t *ToolGetBenefits) handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	benefits, err := t.coverflexClient.GetBenefits()
+	ctx = withRequestLanguage(ctx, request)
+	benefits, err := t.client.GetBenefits(ctx)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("error getting benefits", err), nil
+		return mcp.NewToolResultErrorFromErr(i18n.T(t.client.Locale(ctx), i18n.ErrGetBenefits), err), nil
 	}
 
-	return mcp.NewToolResultJSON(map[string][]coverflex.Benefit{"result": benefits})
+	return mcp.NewToolResultJSON(map[string][]domain.Benefit{"result": benefits})
 }
 
 func (t *ToolGetBenefits) RegisterInServer(s *server.MCPServer) {
 	tool := mcp.NewTool("get_benefits",
 		mcp.WithDescription("Retrieve Coverflex user benefits."),
-		mcp.WithOutputSchema[map[string][]coverflex.Benefit](),
+		withLanguageArgument(),
+		mcp.WithOutputSchema[map[string][]domain.Benefit](),
 	)
 
 	s.AddTool(tool, t.handle)
 }
 
 func (t *ToolGetBenefits) CanBeUsed() bool {
-	return t.coverflexClient != nil && t.coverflexClient.IsLoggedIn()
+	return t.client != nil && t.client.IsLoggedIn()
 }
@@ -0,0 +1,69 @@
+package mcp
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+	"github.com/tembleking/coverflex-mcp/internal/i18n"
+)
+
+type ToolGetCardStatus struct {
+	client domain.CardStatusReader
+}
+
+// cardStatusResult is the lifecycle status of every card, with the company card policy it was assessed against.
+type cardStatusResult struct {
+	Cards []domain.CardStatus `json:"cards"`
+	// Policy is omitted when the company settings could not be retrieved.
+	Policy      *domain.CardRequestPolicy `json:"policy,omitempty"`
+	PolicyError string                    `json:"policy_error,omitempty"`
+}
+
+func NewToolGetCardStatus(client domain.CardStatusReader) *ToolGetCardStatus {
+	return &ToolGetCardStatus{
+		client: client,
+	}
+}
+
+func (t *ToolGetCardStatus) handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx = withRequestLanguage(ctx, request)
+	cards, err := t.client.GetCards(ctx)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(i18n.T(t.client.Locale(ctx), i18n.ErrGetCards), err), nil
+	}
+
+	result := cardStatusResult{}
+	if company, err := t.client.GetCompany(ctx); err != nil {
+		slog.Warn("Assessing cards without the company card policy", "error", err)
+		result.PolicyError = err.Error()
+	} else {
+		policy := domain.NewCardRequestPolicy(company.Settings)
+		result.Policy = &policy
+	}
+	result.Cards = domain.AssessCards(cards, result.Policy, domain.Today())
+	locale := t.client.Locale(ctx)
+	for _, status := range result.Cards {
+		for i, advice := range status.NextActions {
+			status.NextActions[i].Reason = i18n.CardReason(locale, status.Card, advice.ReasonCode)
+		}
+	}
+
+	return mcp.NewToolResultJSON(result)
+}
+
+func (t *ToolGetCardStatus) RegisterInServer(s *server.MCPServer) {
+	tool := mcp.NewTool("get_card_status",
+		mcp.WithDescription("Retrieve the lifecycle status of the Coverflex user cards: days to expiry, whether a virtual card still lacks a physical counterpart, and whether a card is blocked or needs activation. Each card lists the actions the user can take next, according to the company card request policy, which is also returned."),
+		withLanguageArgument(),
+		mcp.WithOutputSchema[cardStatusResult](),
+	)
+
+	s.AddTool(tool, t.handle)
+}
+
+func (t *ToolGetCardStatus) CanBeUsed() bool {
+	return t.client != nil && t.client.IsLoggedIn()
+}
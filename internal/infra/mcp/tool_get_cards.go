@@ -5,37 +5,40 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
-	"github.com/tembleking/coverflex-mcp/internal/infra/coverflex"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+	"github.com/tembleking/coverflex-mcp/internal/i18n"
 )
 
 type ToolGetCards struct {
-	coverflexClient *coverflex.Client
+	client domain.CardsReader
 }
 
-func NewToolGetCards(client *coverflex.Client) *ToolGetCards {
+func NewToolGetCards(client domain.CardsReader) *ToolGetCards {
 	return &ToolGetCards{
-		coverflexClient: client,
+		client: client,
 	}
 }
 
 func (t *ToolGetCards) handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	cards, err := t.coverflexClient.GetCards()
+	ctx = withRequestLanguage(ctx, request)
+	cards, err := t.client.GetCards(ctx)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("error getting cards", err), nil
+		return mcp.NewToolResultErrorFromErr(i18n.T(t.client.Locale(ctx), i18n.ErrGetCards), err), nil
 	}
 
-	return mcp.NewToolResultJSON(map[string][]coverflex.Card{"result": cards})
+	return mcp.NewToolResultJSON(map[string][]domain.Card{"result": cards})
 }
 
 func (t *ToolGetCards) RegisterInServer(s *server.MCPServer) {
 	tool := mcp.NewTool("get_cards",
 		mcp.WithDescription("Retrieve Coverflex user cards."),
-		mcp.WithOutputSchema[map[string][]coverflex.Card](),
+		withLanguageArgument(),
+		mcp.WithOutputSchema[map[string][]domain.Card](),
 	)
 
 	s.AddTool(tool, t.handle)
 }
 
 func (t *ToolGetCards) CanBeUsed() bool {
-	return t.coverflexClient != nil && t.coverflexClient.IsLoggedIn()
+	return t.client != nil && t.client.IsLoggedIn()
 }
@@ -5,23 +5,25 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
-	"github.com/tembleking/coverflex-mcp/internal/infra/coverflex"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+	"github.com/tembleking/coverflex-mcp/internal/i18n"
 )
 
 type ToolGetCompany struct {
-	coverflexClient *coverflex.Client
+	client domain.CompanyReader
 }
 
-func NewToolGetCompany(client *coverflex.Client) *ToolGetCompany {
+func NewToolGetCompany(client domain.CompanyReader) *ToolGetCompany {
 	return &ToolGetCompany{
-		coverflexClient: client,
+		client: client,
 	}
 }
 
 func (t *ToolGetCompany) handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	company, err := t.coverflexClient.GetCompany()
+	ctx = withRequestLanguage(ctx, request)
+	company, err := t.client.GetCompany(ctx)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("error getting company", err), nil
+		return mcp.NewToolResultErrorFromErr(i18n.T(t.client.Locale(ctx), i18n.ErrGetCompany), err), nil
 	}
 
 	return mcp.NewToolResultJSON(company)
@@ -30,12 +32,13 @@ func (t *ToolGetCompany) handle(ctx context.Context, request mcp.CallToolRequest
 func (t *ToolGetCompany) RegisterInServer(s *server.MCPServer) {
 	tool := mcp.NewTool("get_company",
 		mcp.WithDescription("Retrieve Coverflex company information."),
-		mcp.WithOutputSchema[*coverflex.Company](),
+		withLanguageArgument(),
+		mcp.WithOutputSchema[*domain.Company](),
 	)
 
 	s.AddTool(tool, t.handle)
 }
 
 func (t *ToolGetCompany) CanBeUsed() bool {
-	return t.coverflexClient != nil && t.coverflexClient.IsLoggedIn()
+	return t.client != nil && t.client.IsLoggedIn()
 }
@@ -5,23 +5,25 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
-	"github.com/tembleking/coverflex-mcp/internal/infra/coverflex"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+	"github.com/tembleking/coverflex-mcp/internal/i18n"
 )
 
 type ToolGetCompensation struct {
-	coverflexClient *coverflex.Client
+	client domain.CompensationReader
 }
 
-func NewToolGetCompensation(client *coverflex.Client) *ToolGetCompensation {
+func NewToolGetCompensation(client domain.CompensationReader) *ToolGetCompensation {
 	return &ToolGetCompensation{
-		coverflexClient: client,
+		client: client,
 	}
 }
 
 func (t *ToolGetCompensation) handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	compensation, err := t.coverflexClient.GetCompensation()
+	ctx = withRequestLanguage(ctx, request)
+	compensation, err := t.client.GetCompensation(ctx)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("error getting compensation", err), nil
+		return mcp.NewToolResultErrorFromErr(i18n.T(t.client.Locale(ctx), i18n.ErrGetCompensation), err), nil
 	}
 
 	return mcp.NewToolResultJSON(compensation)
@@ -30,12 +32,13 @@ func (t *ToolGetCompensation) handle(ctx context.Context, request mcp.CallToolRe
 func (t *ToolGetCompensation) RegisterInServer(s *server.MCPServer) {
 	tool := mcp.NewTool("get_compensation",
 		mcp.WithDescription("Retrieve Coverflex user compensation summary."),
-		mcp.WithOutputSchema[*coverflex.CompensationSummary](),
+		withLanguageArgument(),
+		mcp.WithOutputSchema[*domain.Compensation](),
 	)
 
 	s.AddTool(tool, t.handle)
 }
 
 func (t *ToolGetCompensation) CanBeUsed() bool {
-	return t.coverflexClient != nil && t.coverflexClient.IsLoggedIn()
+	return t.client != nil && t.client.IsLoggedIn()
 }
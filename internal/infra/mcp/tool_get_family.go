@@ -5,37 +5,40 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
-	"github.com/tembleking/coverflex-mcp/internal/infra/coverflex"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+	"github.com/tembleking/coverflex-mcp/internal/i18n"
 )
 
 type ToolGetFamily struct {
-	coverflexClient *coverflex.Client
+	client domain.FamilyReader
 }
 
-func NewToolGetFamily(client *coverflex.Client) *ToolGetFamily {
+func NewToolGetFamily(client domain.FamilyReader) *ToolGetFamily {
 	return &ToolGetFamily{
-		coverflexClient: client,
+		client: client,
 	}
 }
 
 func (t *ToolGetFamily) handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	family, err := t.coverflexClient.GetFamily()
+	ctx = withRequestLanguage(ctx, request)
+	family, err := t.client.GetFamily(ctx)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("error getting family members", err), nil
+		return mcp.NewToolResultErrorFromErr(i18n.T(t.client.Locale(ctx), i18n.ErrGetFamily), err), nil
 	}
 
-	return mcp.NewToolResultJSON(map[string][]coverflex.FamilyMember{"result": family})
+	return mcp.NewToolResultJSON(map[string][]domain.FamilyMember{"result": family})
 }
 
 func (t *ToolGetFamily) RegisterInServer(s *server.MCPServer) {
 	tool := mcp.NewTool("get_family",
 		mcp.WithDescription("Retrieve Coverflex user family members."),
-		mcp.WithOutputSchema[map[string][]coverflex.FamilyMember](),
+		withLanguageArgument(),
+		mcp.WithOutputSchema[map[string][]domain.FamilyMember](),
 	)
 
 	s.AddTool(tool, t.handle)
 }
 
 func (t *ToolGetFamily) CanBeUsed() bool {
-	return t.coverflexClient != nil && t.coverflexClient.IsLoggedIn()
+	return t.client != nil && t.client.IsLoggedIn()
 }
@@ -0,0 +1,73 @@
+package mcp
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+	"github.com/tembleking/coverflex-mcp/internal/i18n"
+)
+
+type ToolGetFamilyInsights struct {
+	client domain.FamilyInsightsReader
+}
+
+// familyInsightsResult is the insights of every family member.
+type familyInsightsResult struct {
+	Members []domain.FamilyMemberInsight `json:"members"`
+	// BenefitsError is set when the benefits could not be retrieved, so no member lists eligible benefits.
+	BenefitsError string `json:"benefits_error,omitempty"`
+}
+
+func NewToolGetFamilyInsights(client domain.FamilyInsightsReader) *ToolGetFamilyInsights {
+	return &ToolGetFamilyInsights{
+		client: client,
+	}
+}
+
+func (t *ToolGetFamilyInsights) handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx = withRequestLanguage(ctx, request)
+	horizonDays := request.GetInt("horizon_days", domain.DefaultEligibilityHorizonDays)
+
+	family, err := t.client.GetFamily(ctx)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(i18n.T(t.client.Locale(ctx), i18n.ErrGetFamily), err), nil
+	}
+
+	result := familyInsightsResult{}
+	benefits, err := t.client.GetBenefits(ctx)
+	if err != nil {
+		slog.Warn("Assessing family members without the benefits", "error", err)
+		result.BenefitsError = err.Error()
+	}
+	result.Members = domain.AssessFamily(family, benefits, domain.Today(), horizonDays)
+	locale := t.client.Locale(ctx)
+	for _, member := range result.Members {
+		for i, benefit := range member.EligibleBenefits {
+			member.EligibleBenefits[i].Reason = i18n.EligibilityReason(locale, benefit.Category)
+		}
+	}
+
+	return mcp.NewToolResultJSON(result)
+}
+
+func (t *ToolGetFamilyInsights) RegisterInServer(s *server.MCPServer) {
+	tool := mcp.NewTool("get_family_insights",
+		mcp.WithDescription("Retrieve insights about the Coverflex user family members: age, whether they are a dependant, their next birthday, the benefits that apply to them (e.g. childcare, education or health) and the upcoming birthdays that change their eligibility, such as a child aging out of childcare."),
+		mcp.WithNumber("horizon_days",
+			mcp.Description("How many days ahead to look for birthdays that change benefit eligibility."),
+			mcp.DefaultNumber(domain.DefaultEligibilityHorizonDays),
+			mcp.Min(1),
+		),
+		withLanguageArgument(),
+		mcp.WithOutputSchema[familyInsightsResult](),
+	)
+
+	s.AddTool(tool, t.handle)
+}
+
+func (t *ToolGetFamilyInsights) CanBeUsed() bool {
+	return t.client != nil && t.client.IsLoggedIn()
+}
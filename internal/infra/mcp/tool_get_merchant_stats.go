@@ -0,0 +1,118 @@
+package mcp
+
+import (
+	"context"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+	"github.com/tembleking/coverflex-mcp/internal/i18n"
+)
+
+const (
+	getMerchantStatsDefaultLimit    = 20
+	getMerchantStatsMaxLimit        = 200
+	getMerchantStatsDefaultMaxPages = 20
+	getMerchantStatsMaxPages        = 100
+)
+
+type ToolGetMerchantStats struct {
+	client domain.OperationsPeriodIterator
+}
+
+// merchantStatsResult is the spending by merchant, and whether the limits left merchants or operations out.
+type merchantStatsResult struct {
+	domain.MerchantsSummary
+	// Truncated reports that more merchants were left out by the limit.
+	Truncated bool `json:"truncated"`
+	// Incomplete reports that max_pages was reached, so older operations were left out of the statistics.
+	Incomplete bool `json:"incomplete"`
+}
+
+func NewToolGetMerchantStats(client domain.OperationsPeriodIterator) *ToolGetMerchantStats {
+	return &ToolGetMerchantStats{client: client}
+}
+
+func (t *ToolGetMerchantStats) handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx = withRequestSource(withRequestLanguage(ctx, request), request)
+	locale := t.client.Locale(ctx)
+	limit := min(max(request.GetInt("limit", getMerchantStatsDefaultLimit), 1), getMerchantStatsMaxLimit)
+	maxPages := min(max(request.GetInt("max_pages", getMerchantStatsDefaultMaxPages), 1), getMerchantStatsMaxPages)
+	categories := requestEnumFilter[domain.CategorySlug](request, "category", "exclude_category")
+	products := domain.ParseValueFilter[string](request.GetString("product", ""))
+
+	order, err := domain.ParseMerchantStatsSort(request.GetString("sort_by", ""))
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(i18n.T(locale, i18n.ErrInvalidFilter), err), nil
+	}
+	period, err := requestPeriodOr(ctx, request, t.client, domain.RangeCurrentMonth)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(i18n.T(locale, i18n.ErrInvalidFilter), err), nil
+	}
+	if err := period.Validate(); err != nil {
+		return mcp.NewToolResultErrorFromErr(i18n.T(locale, i18n.ErrInvalidFilter), err), nil
+	}
+
+	operations, incomplete, err := domain.LoadSpendingOperations(ctx, t.client, period, maxPages)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(i18n.T(locale, i18n.ErrGetOperations), err), nil
+	}
+	// The refunds are linked before filtering, so the filters cannot hide a refund from its payment.
+	operations = slices.DeleteFunc(operations, func(operation domain.Operation) bool {
+		return !categories.Matches(operation.CategorySlug) || !products.Matches(operation.ProductSlug)
+	})
+	summary, err := domain.SummarizeMerchants(operations, period, order)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(i18n.T(locale, i18n.ErrGetOperations), err), nil
+	}
+
+	result := merchantStatsResult{MerchantsSummary: *summary, Incomplete: incomplete}
+	if len(result.Merchants) > limit {
+		result.Merchants = result.Merchants[:limit]
+		result.Truncated = true
+	}
+	return mcp.NewToolResultJSON(result)
+}
+
+func (t *ToolGetMerchantStats) RegisterInServer(s *server.MCPServer) {
+	orders := make([]string, 0, len(domain.MerchantStatsSorts))
+	for _, order := range domain.MerchantStatsSorts {
+		orders = append(orders, string(order))
+	}
+
+	tool := mcp.NewTool("get_merchant_stats",
+		mcp.WithDescription("Show where the Coverflex money is spent: for each merchant over a period, the total spent, the number of visits, the average and median ticket, the first and last visit, and its share of the spending at all the merchants. Variants of a merchant name differing in case, accents or trailing store codes are grouped together. Amounts are exact and net of the refunds linked to the payments; refunds whose payment was not found, rollovers and reversed or failed payments are left out. Defaults to the current month."),
+		withPeriodArguments(),
+		withEnumFilterArguments("category", "exclude_category", "benefit categories", domain.EnumStrings(domain.KnownCategorySlugs)),
+		mcp.WithString("product",
+			mcp.Description("The product slugs to count. "+multiValueHint),
+		),
+		mcp.WithString("sort_by",
+			mcp.Description("The order of the merchants, the largest first."),
+			mcp.Enum(orders...),
+			mcp.DefaultString(string(domain.MerchantStatsByTotal)),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("The maximum number of merchants to return."),
+			mcp.DefaultNumber(getMerchantStatsDefaultLimit),
+			mcp.Min(1),
+			mcp.Max(getMerchantStatsMaxLimit),
+		),
+		mcp.WithNumber("max_pages",
+			mcp.Description("The maximum number of pages of 50 operations to walk."),
+			mcp.DefaultNumber(getMerchantStatsDefaultMaxPages),
+			mcp.Min(1),
+			mcp.Max(getMerchantStatsMaxPages),
+		),
+		withSourceArgument(),
+		withLanguageArgument(),
+		mcp.WithOutputSchema[merchantStatsResult](),
+	)
+
+	s.AddTool(tool, t.handle)
+}
+
+func (t *ToolGetMerchantStats) CanBeUsed() bool {
+	return t.client != nil && t.client.IsLoggedIn()
+}
@@ -0,0 +1,126 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+	"github.com/tembleking/coverflex-mcp/internal/i18n"
+)
+
+// getOperationCounterpartPages caps the pages walked to find the counterparts of an operation.
+const getOperationCounterpartPages = 20
+
+type ToolGetOperation struct {
+	client   domain.OperationDetailReader
+	renderer *i18n.DescriptionRenderer
+}
+
+// operationDetailResult is an operation with the benefit it belongs to and its related operations.
+type operationDetailResult struct {
+	Operation domain.Operation `json:"operation"`
+	Benefit   *domain.Benefit  `json:"benefit,omitempty"`
+	Product   *domain.Product  `json:"product,omitempty"`
+	// Counterparts are the refunds of a payment, the payment a refund is for, or the other side of a rollover.
+	Counterparts []domain.OperationLink `json:"counterparts"`
+	// Errors reports the enrichments that failed, keyed by "benefit" or "counterparts".
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+func (r *operationDetailResult) setError(section string, err error) {
+	if r.Errors == nil {
+		r.Errors = make(map[string]string)
+	}
+	r.Errors[section] = err.Error()
+}
+
+func NewToolGetOperation(client domain.OperationDetailReader, renderer *i18n.DescriptionRenderer) *ToolGetOperation {
+	return &ToolGetOperation{
+		client:   client,
+		renderer: renderer,
+	}
+}
+
+func (t *ToolGetOperation) handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx = withRequestSource(withRequestLanguage(ctx, request), request)
+	locale := t.client.Locale(ctx)
+
+	id, err := request.RequireString("id")
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(i18n.T(locale, i18n.ErrGetOperation), err), nil
+	}
+	operation, err := t.client.GetOperation(ctx, id)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(i18n.T(locale, i18n.ErrGetOperation), err), nil
+	}
+
+	result := operationDetailResult{Counterparts: []domain.OperationLink{}}
+	if benefits, err := t.client.GetBenefits(ctx); err != nil {
+		slog.Warn("Returning the operation without its benefit", "error", err)
+		result.setError("benefit", err)
+	} else {
+		result.Benefit, result.Product = domain.FindBenefit(benefits, *operation)
+	}
+
+	candidates, err := t.counterpartCandidates(ctx, *operation)
+	if err != nil {
+		slog.Warn("Looking for counterparts among the operations fetched before the error", "error", err)
+		result.setError("counterparts", err)
+	}
+	// The refunds are linked among the operations around it, which include the operation itself.
+	isOperation := func(candidate domain.Operation) bool { return candidate.ID == operation.ID }
+	if !slices.ContainsFunc(candidates, isOperation) {
+		candidates = append(candidates, *operation)
+	}
+	candidates = domain.LinkRefunds(candidates)
+	linked := candidates[slices.IndexFunc(candidates, isOperation)]
+	result.Operation = describeOperations(t.renderer, locale, []domain.Operation{linked})[0]
+
+	for _, link := range domain.FindCounterparts(linked, candidates) {
+		link.Operation = describeOperations(t.renderer, locale, []domain.Operation{link.Operation})[0]
+		result.Counterparts = append(result.Counterparts, link)
+	}
+
+	return mcp.NewToolResultJSON(result)
+}
+
+// counterpartCandidates returns the operations close enough in time to the operation to be its counterparts.
+func (t *ToolGetOperation) counterpartCandidates(ctx context.Context, operation domain.Operation) ([]domain.Operation, error) {
+	var candidates []domain.Operation
+	for candidate, err := range t.client.AllOperations(ctx,
+		domain.WithOperationsMaxPages(getOperationCounterpartPages),
+		domain.WithOperationsPeriod(domain.CounterpartWindow(operation)),
+	) {
+		if errors.Is(err, domain.ErrPageLimitReached) {
+			break
+		}
+		if err != nil {
+			return candidates, err
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, nil
+}
+
+func (t *ToolGetOperation) RegisterInServer(s *server.MCPServer) {
+	tool := mcp.NewTool("get_operation",
+		mcp.WithDescription("Retrieve a single Coverflex operation by its ID, with its human-readable 'description', the benefit and product it belongs to, and its counterparts: the refunds of a payment ('refunds'), the payment a refund is for ('refund_of'), or the other side of a rollover. Use it to look at an operation seen earlier without paging through the history again."),
+		mcp.WithString("id",
+			mcp.Required(),
+			mcp.Description("The ID of the operation."),
+		),
+		withSourceArgument(),
+		withLanguageArgument(),
+		mcp.WithOutputSchema[operationDetailResult](),
+	)
+
+	s.AddTool(tool, t.handle)
+}
+
+func (t *ToolGetOperation) CanBeUsed() bool {
+	return t.client != nil && t.client.IsLoggedIn()
+}
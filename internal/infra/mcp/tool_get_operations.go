@@ -2,58 +2,116 @@ package mcp
 
 import (
 	"context"
+	"errors"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
-	"github.com/tembleking/coverflex-mcp/internal/infra/coverflex"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+	"github.com/tembleking/coverflex-mcp/internal/i18n"
 )
 
 type ToolGetOperations struct {
-	coverflexClient *coverflex.Client
+	client   domain.OperationsPagesReader
+	renderer *i18n.DescriptionRenderer
 }
 
-func NewToolGetOperations(client *coverflex.Client) *ToolGetOperations {
+// operationsPageResult is a page of operations and the cursor to the next one.
+type operationsPageResult struct {
+	// Result holds the operations, with the refunds linked to the payments within the same page.
+	Result []domain.Operation `json:"result"`
+	// NextCursor resumes right after the last operation returned. It is empty when there are no more operations.
+	NextCursor string `json:"next_cursor,omitempty"`
+	// Incomplete reports that the merchant or category filters matched too few operations within the pages
+	// walked, so the page holds fewer operations than asked for.
+	Incomplete bool `json:"incomplete,omitempty"`
+}
+
+func NewToolGetOperations(client domain.OperationsPagesReader, renderer *i18n.DescriptionRenderer) *ToolGetOperations {
 	return &ToolGetOperations{
-		coverflexClient: client,
+		client:   client,
+		renderer: renderer,
 	}
 }
 
 func (t *ToolGetOperations) handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	page := request.GetInt("page", 0)
+	ctx = withRequestSource(withRequestLanguage(ctx, request), request)
+	pageNumber := request.GetInt("page", 0)
 	perPage := request.GetInt("per_page", 0)
-	filterType := request.GetString("filter_type", "")
+	filters, err := requestFilters(ctx, request, t.client)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(i18n.T(t.client.Locale(ctx), i18n.ErrInvalidFilter), err), nil
+	}
 
-	var opts []coverflex.GetOperationsOption
-	if page > 0 {
-		opts = append(opts, coverflex.WithOperationsPage(int(page)))
+	var opts []domain.GetOperationsOption
+	if pageNumber > 0 {
+		opts = append(opts, domain.WithOperationsPage(int(pageNumber)))
 	}
 	if perPage > 0 {
-		opts = append(opts, coverflex.WithOperationsPerPage(int(perPage)))
-	}
-	if filterType != "" {
-		opts = append(opts, coverflex.WithOperationsFilterType(filterType))
+		opts = append(opts, domain.WithOperationsPerPage(int(perPage)))
 	}
+	opts = append(opts, domain.WithOperationsFilters(filters))
 
-	operations, err := t.coverflexClient.GetOperations(opts...)
+	page, err := t.fetchPage(ctx, request.GetString("cursor", ""), opts...)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("error getting operations", err), nil
+		return mcp.NewToolResultErrorFromErr(i18n.T(t.client.Locale(ctx), i18n.ErrGetOperations), err), nil
 	}
 
-	return mcp.NewToolResultJSON(map[string][]coverflex.Operation{"result": operations})
+	operations := domain.LinkRefunds(page.Operations)
+	result := operationsPageResult{Result: describeOperations(t.renderer, t.client.Locale(ctx), operations)}
+	if page.Next != nil {
+		result.NextCursor = page.Next.Encode()
+	}
+	result.Incomplete = page.Partial
+	return mcp.NewToolResultJSON(result)
+}
+
+// fetchPage returns the page after the cursor if one is given, or else the numbered page with a cursor to
+// the next one. The cursor hints the API page to resume at, unknown when filtering client-side.
+func (t *ToolGetOperations) fetchPage(ctx context.Context, cursor string, opts ...domain.GetOperationsOption) (*domain.OperationsCursorPage, error) {
+	if cursor != "" {
+		after, err := domain.ParseOperationsCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		return t.client.GetOperationsAfter(ctx, after, opts...)
+	}
+	params := domain.NewGetOperationsParams(opts...)
+	if params.Page == 1 && params.Filters.HasClientSide() {
+		// Walking from the first operation, a page cut short by the page limit still gets a cursor to resume.
+		return t.client.GetOperationsAfter(ctx, nil, opts...)
+	}
+
+	operations, err := t.client.GetOperations(ctx, opts...)
+	partial := errors.Is(err, domain.ErrPageLimitReached)
+	if err != nil && !partial {
+		return nil, err
+	}
+	result := &domain.OperationsCursorPage{Operations: operations, Partial: partial}
+	if len(operations) == params.PerPage {
+		hint := params.Page
+		if params.Filters.HasClientSide() {
+			hint = 1
+		}
+		result.Next = domain.NewOperationsCursor(operations, nil, hint, params.PerPage)
+	}
+	return result, nil
 }
 
 func (t *ToolGetOperations) RegisterInServer(s *server.MCPServer) {
 	tool := mcp.NewTool("get_operations",
-		mcp.WithDescription("Retrieve Coverflex user operations with optional pagination and filtering. To retrieve all operations, the LLM needs to paginate. Note: 'rollover' and 'rollover top-up' operations are internal transfers of funds between different benefit categories."),
-		mcp.WithNumber("page", mcp.Description("The page number for pagination."), mcp.DefaultNumber(1)),
+		mcp.WithDescription("Retrieve a single page of Coverflex user operations with optional filtering. To continue with the next page, pass the returned 'next_cursor' as 'cursor': unlike page numbers, it stays stable when new operations arrive between calls, so no operation is returned twice or skipped. To retrieve many operations at once, use 'get_all_operations' instead of paginating. Note: 'rollover' and 'rollover top-up' operations are internal transfers of funds between different benefit categories. Each operation includes a human-readable 'description' rendered from its description tag and params. Refunds are linked ('refunds', 'refunded_amount', 'refund_of') only to payments on the same page, so a refund whose payment is on another page is returned unlinked; use 'get_all_operations' or 'get_spending_summary' to link refunds across pages. Filtering by merchant or category walks a limited number of pages: when 'incomplete' is set, fewer operations matched within them, so set 'from' to narrow the period or continue with 'next_cursor' when one is returned."),
+		mcp.WithString("cursor", mcp.Description("The 'next_cursor' of a previous call, to get the operations right after it. Takes precedence over 'page'.")),
+		mcp.WithNumber("page", mcp.Description("The page number for pagination. Prefer 'cursor' to go through the pages."), mcp.DefaultNumber(1)),
 		mcp.WithNumber("per_page", mcp.Description("The number of items per page."), mcp.DefaultNumber(20)),
-		mcp.WithString("filter_type", mcp.Description("The type of operation to filter by.")),
-		mcp.WithOutputSchema[map[string][]coverflex.Operation](),
+		withOperationFilterArguments(),
+		withSourceArgument(),
+		withLanguageArgument(),
+		mcp.WithOutputSchema[operationsPageResult](),
 	)
 
 	s.AddTool(tool, t.handle)
 }
 
 func (t *ToolGetOperations) CanBeUsed() bool {
-	return t.coverflexClient != nil && t.coverflexClient.IsLoggedIn()
+	return t.client != nil && t.client.IsLoggedIn()
 }
@@ -0,0 +1,140 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+	"github.com/tembleking/coverflex-mcp/internal/i18n"
+)
+
+// fakeOperationsPages serves a fixed list of operations through the domain.OperationsPagesReader port and
+// records how it was called.
+type fakeOperationsPages struct {
+	operations []domain.Operation
+	err        error
+
+	// languages are the languages set in the context of every call, and params the parameters of the last one.
+	languages []string
+	params    *domain.GetOperationsParams
+	after     bool
+}
+
+func (f *fakeOperationsPages) IsLoggedIn() bool { return true }
+
+func (f *fakeOperationsPages) Locale(ctx context.Context) string {
+	if language, ok := domain.LanguageFrom(ctx); ok {
+		return language
+	}
+	return "en"
+}
+
+func (f *fakeOperationsPages) record(ctx context.Context, opts []domain.GetOperationsOption) {
+	language, _ := domain.LanguageFrom(ctx)
+	f.languages = append(f.languages, language)
+	f.params = domain.NewGetOperationsParams(opts...)
+}
+
+func (f *fakeOperationsPages) GetOperations(ctx context.Context, opts ...domain.GetOperationsOption) ([]domain.Operation, error) {
+	f.record(ctx, opts)
+	if f.err != nil {
+		return nil, f.err
+	}
+	start := min((f.params.Page-1)*f.params.PerPage, len(f.operations))
+	return f.operations[start:min(start+f.params.PerPage, len(f.operations))], nil
+}
+
+func (f *fakeOperationsPages) GetOperationsAfter(ctx context.Context, cursor *domain.OperationsCursor, opts ...domain.GetOperationsOption) (*domain.OperationsCursorPage, error) {
+	f.record(ctx, opts)
+	f.after = true
+	if f.err != nil {
+		return nil, f.err
+	}
+	return domain.OperationsPageAfter(ctx, func(ctx context.Context, opts ...domain.GetOperationsOption) ([]domain.Operation, error) {
+		return f.GetOperations(ctx, opts...)
+	}, cursor, opts...)
+}
+
+func (f *fakeOperationsPages) GetCompensation(context.Context) (*domain.Compensation, error) {
+	return nil, errors.New("not used")
+}
+
+func callTool(t *testing.T, handle func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), arguments map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	var request mcp.CallToolRequest
+	request.Params.Arguments = arguments
+	result, err := handle(t.Context(), request)
+	if err != nil {
+		t.Fatalf("handle() error = %v", err)
+	}
+	return result
+}
+
+func TestToolGetOperations(t *testing.T) {
+	at := func(day int) domain.Timestamp {
+		return domain.NewTimestamp(time.Date(2025, time.March, day, 12, 0, 0, 0, time.UTC))
+	}
+	fake := &fakeOperationsPages{operations: []domain.Operation{
+		{ID: "refund", Amount: domain.NewMoney(500, domain.DefaultCurrency), ExecutedAt: at(9), CategorySlug: domain.CategoryMeal,
+			MerchantName: "Pingo Doce", Type: domain.OperationTypeRefund},
+		{ID: "meal", Amount: domain.NewMoney(-500, domain.DefaultCurrency), ExecutedAt: at(8), CategorySlug: domain.CategoryMeal,
+			MerchantName: "Pingo Doce", Type: domain.OperationTypeCardTransaction, IsDebit: true},
+		{ID: "health", Amount: domain.NewMoney(-3000, domain.DefaultCurrency), ExecutedAt: at(7), CategorySlug: domain.CategoryHealth,
+			Type: domain.OperationTypeCardTransaction, IsDebit: true},
+	}}
+	tool := NewToolGetOperations(fake, i18n.NewDescriptionRenderer())
+
+	t.Run("a numbered page links its refunds and gets a cursor", func(t *testing.T) {
+		result := callTool(t, tool.handle, map[string]any{"per_page": 2, "language": "pt"})
+		page, ok := result.StructuredContent.(operationsPageResult)
+		if result.IsError || !ok {
+			t.Fatalf("result = %+v, want a page of operations", result)
+		}
+		if len(page.Result) != 2 || page.Result[0].RefundOf != "meal" || page.NextCursor == "" {
+			t.Errorf("page = %+v, want the refund linked to the payment and a next cursor", page)
+		}
+		if fake.after || fake.languages[len(fake.languages)-1] != "pt" {
+			t.Errorf("fetched after a cursor %v in %q, want the numbered page in pt", fake.after, fake.languages)
+		}
+
+		next := callTool(t, tool.handle, map[string]any{"per_page": 2, "cursor": page.NextCursor})
+		rest, _ := next.StructuredContent.(operationsPageResult)
+		if len(rest.Result) != 1 || rest.Result[0].ID != "health" || rest.NextCursor != "" {
+			t.Errorf("next page = %+v, want only the health operation and no cursor", rest)
+		}
+	})
+
+	t.Run("client-side filters walk from a cursor", func(t *testing.T) {
+		fake.after = false
+		result := callTool(t, tool.handle, map[string]any{"category": []any{"health"}, "exclude_status": []any{"declined"}})
+		page, _ := result.StructuredContent.(operationsPageResult)
+		if len(page.Result) != 1 || page.Result[0].ID != "health" || !fake.after {
+			t.Errorf("page = %+v, after a cursor %v, want only the health operation walked from a cursor", page, fake.after)
+		}
+		filters := fake.params.Filters
+		if !slices.Equal(filters.Categories.Include, []domain.CategorySlug{domain.CategoryHealth}) ||
+			!slices.Equal(filters.Statuses.Exclude, []domain.OperationStatus{domain.OperationStatusDeclined}) {
+			t.Errorf("filters = %+v, want the health category and no declined status", filters)
+		}
+	})
+
+	t.Run("errors are reported in the request language", func(t *testing.T) {
+		failing := &fakeOperationsPages{err: errors.New("boom")}
+		result := callTool(t, NewToolGetOperations(failing, i18n.NewDescriptionRenderer()).handle, map[string]any{"language": "es", "page": 2})
+		text := result.Content[0].(mcp.TextContent).Text
+		if !result.IsError || !strings.HasPrefix(text, i18n.T("es", i18n.ErrGetOperations)) || !strings.Contains(text, "boom") {
+			t.Errorf("result = %q, error %v, want the Spanish error with its cause", text, result.IsError)
+		}
+	})
+
+	t.Run("an invalid cursor is an error result", func(t *testing.T) {
+		if result := callTool(t, tool.handle, map[string]any{"cursor": "not a cursor"}); !result.IsError {
+			t.Errorf("result = %+v, want an error", result)
+		}
+	})
+}
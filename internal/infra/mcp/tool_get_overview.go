@@ -0,0 +1,43 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+	"github.com/tembleking/coverflex-mcp/internal/i18n"
+)
+
+type ToolGetOverview struct {
+	client   domain.OverviewReader
+	renderer *i18n.DescriptionRenderer
+}
+
+func NewToolGetOverview(client domain.OverviewReader, renderer *i18n.DescriptionRenderer) *ToolGetOverview {
+	return &ToolGetOverview{
+		client:   client,
+		renderer: renderer,
+	}
+}
+
+func (t *ToolGetOverview) handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx = withRequestLanguage(ctx, request)
+	overview := t.client.GetOverview(ctx)
+	overview.Operations = describeOperations(t.renderer, t.client.Locale(ctx), overview.Operations)
+	return mcp.NewToolResultJSON(overview)
+}
+
+func (t *ToolGetOverview) RegisterInServer(s *server.MCPServer) {
+	tool := mcp.NewTool("get_overview",
+		mcp.WithDescription("Retrieve a full picture of the Coverflex account in a single call: company, compensation, benefits, cards, family members and the most recent operations, with the refunds among them linked to their payments. Sections that could not be fetched are omitted and their error is reported in 'errors', keyed by section name."),
+		withLanguageArgument(),
+		mcp.WithOutputSchema[domain.Overview](),
+	)
+
+	s.AddTool(tool, t.handle)
+}
+
+func (t *ToolGetOverview) CanBeUsed() bool {
+	return t.client != nil && t.client.IsLoggedIn()
+}
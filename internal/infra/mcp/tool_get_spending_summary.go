@@ -0,0 +1,86 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+	"github.com/tembleking/coverflex-mcp/internal/i18n"
+)
+
+const (
+	getSpendingSummaryDefaultMaxPages = 20
+	getSpendingSummaryMaxPages        = 100
+)
+
+type ToolGetSpendingSummary struct {
+	client domain.OperationsPeriodIterator
+}
+
+// spendingSummaryResult is the spending summary, and whether the page limit left operations out of it.
+type spendingSummaryResult struct {
+	domain.SpendingSummary
+	// Incomplete reports that max_pages was reached, so older operations were left out of the summary.
+	Incomplete bool `json:"incomplete"`
+}
+
+func NewToolGetSpendingSummary(client domain.OperationsPeriodIterator) *ToolGetSpendingSummary {
+	return &ToolGetSpendingSummary{client: client}
+}
+
+func (t *ToolGetSpendingSummary) handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx = withRequestSource(withRequestLanguage(ctx, request), request)
+	locale := t.client.Locale(ctx)
+	maxPages := min(max(request.GetInt("max_pages", getSpendingSummaryDefaultMaxPages), 1), getSpendingSummaryMaxPages)
+
+	granularity, err := domain.ParseSpendingGranularity(request.GetString("group_by", ""))
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(i18n.T(locale, i18n.ErrInvalidFilter), err), nil
+	}
+	period, err := requestPeriodOr(ctx, request, t.client, domain.RangeCurrentMonth)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(i18n.T(locale, i18n.ErrInvalidFilter), err), nil
+	}
+	if err := period.Validate(); err != nil {
+		return mcp.NewToolResultErrorFromErr(i18n.T(locale, i18n.ErrInvalidFilter), err), nil
+	}
+
+	summary, incomplete, err := domain.LoadSpending(ctx, t.client, period, granularity, maxPages)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(i18n.T(locale, i18n.ErrGetOperations), err), nil
+	}
+	return mcp.NewToolResultJSON(spendingSummaryResult{SpendingSummary: *summary, Incomplete: incomplete})
+}
+
+func (t *ToolGetSpendingSummary) RegisterInServer(s *server.MCPServer) {
+	granularities := make([]string, 0, len(domain.SpendingGranularities))
+	for _, granularity := range domain.SpendingGranularities {
+		granularities = append(granularities, string(granularity))
+	}
+
+	tool := mcp.NewTool("get_spending_summary",
+		mcp.WithDescription("Summarize the Coverflex spending over a period: the total spent, broken down by benefit category and product and by day, week or month. Totals are exact, net of the refunds linked to each payment, and leave out rollovers and reversed or failed payments; a refund no payment was found for is subtracted on the day it was received. Defaults to the current month."),
+		withPeriodArguments(),
+		mcp.WithString("group_by",
+			mcp.Description("The length of the periods the spending is broken down by. Weeks start on Monday and are keyed by ISO week, e.g. 2025-W10."),
+			mcp.Enum(granularities...),
+			mcp.DefaultString(string(domain.SpendingByMonth)),
+		),
+		mcp.WithNumber("max_pages",
+			mcp.Description("The maximum number of pages of 50 operations to walk."),
+			mcp.DefaultNumber(getSpendingSummaryDefaultMaxPages),
+			mcp.Min(1),
+			mcp.Max(getSpendingSummaryMaxPages),
+		),
+		withSourceArgument(),
+		withLanguageArgument(),
+		mcp.WithOutputSchema[spendingSummaryResult](),
+	)
+
+	s.AddTool(tool, t.handle)
+}
+
+func (t *ToolGetSpendingSummary) CanBeUsed() bool {
+	return t.client != nil && t.client.IsLoggedIn()
+}
@@ -5,21 +5,21 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
-	"github.com/tembleking/coverflex-mcp/internal/infra/coverflex"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
 )
 
 type ToolIsLoggedIn struct {
-	coverflexClient *coverflex.Client
+	client domain.Session
 }
 
-func NewToolIsLoggedIn(client *coverflex.Client) *ToolIsLoggedIn {
+func NewToolIsLoggedIn(client domain.Session) *ToolIsLoggedIn {
 	return &ToolIsLoggedIn{
-		coverflexClient: client,
+		client: client,
 	}
 }
 
 func (t *ToolIsLoggedIn) handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	return mcp.NewToolResultJSON(map[string]bool{"isLoggedIn": t.coverflexClient.IsLoggedIn()})
+	return mcp.NewToolResultJSON(map[string]bool{"isLoggedIn": t.client.IsLoggedIn()})
 }
 
 func (t *ToolIsLoggedIn) RegisterInServer(s *server.MCPServer) {
@@ -32,5 +32,5 @@ func (t *ToolIsLoggedIn) RegisterInServer(s *server.MCPServer) {
 }
 
 func (t *ToolIsLoggedIn) CanBeUsed() bool {
-	return t.coverflexClient != nil
+	return t.client != nil
 }
@@ -0,0 +1,74 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+	"github.com/tembleking/coverflex-mcp/internal/i18n"
+)
+
+const (
+	listOperationTypesPerPage      = 50
+	listOperationTypesMaxPages     = 10
+	listOperationTypesDefaultPages = 3
+)
+
+type ToolListOperationTypes struct {
+	client domain.OperationsReader
+}
+
+type operationTypesResult struct {
+	Types              []domain.EnumValue `json:"types"`
+	Statuses           []domain.EnumValue `json:"statuses"`
+	Categories         []domain.EnumValue `json:"categories"`
+	ObservedOperations int                `json:"observed_operations"`
+}
+
+func NewToolListOperationTypes(client domain.OperationsReader) *ToolListOperationTypes {
+	return &ToolListOperationTypes{
+		client: client,
+	}
+}
+
+func (t *ToolListOperationTypes) handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	pages := min(max(request.GetInt("pages", listOperationTypesDefaultPages), 1), listOperationTypesMaxPages)
+
+	vocabulary := domain.NewOperationVocabulary()
+	observed := 0
+	for page := 1; page <= pages; page++ {
+		operations, err := t.client.GetOperations(ctx, domain.WithOperationsPage(page), domain.WithOperationsPerPage(listOperationTypesPerPage))
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr(i18n.T(t.client.Locale(ctx), i18n.ErrGetOperations), err), nil
+		}
+		for _, operation := range operations {
+			vocabulary.Observe(operation.Type, operation.Status, operation.CategorySlug)
+		}
+		observed += len(operations)
+		if len(operations) < listOperationTypesPerPage {
+			break
+		}
+	}
+
+	return mcp.NewToolResultJSON(operationTypesResult{
+		Types:              vocabulary.Types(),
+		Statuses:           vocabulary.Statuses(),
+		Categories:         vocabulary.Categories(),
+		ObservedOperations: observed,
+	})
+}
+
+func (t *ToolListOperationTypes) RegisterInServer(s *server.MCPServer) {
+	tool := mcp.NewTool("list_operation_types",
+		mcp.WithDescription("List the values of the operation type, status and category fields. It merges the values known by the server, collected from past Coverflex API responses, with the ones observed in the most recent operations of the account, reporting for each value whether it is known and how many times it was observed. The known values are the ones accepted by the 'filter_type', 'status' and 'category' filters of 'get_operations' and their 'exclude_' counterparts; a value observed but not known cannot be filtered by."),
+		mcp.WithNumber("pages", mcp.Description("The number of pages of 50 recent operations to inspect."), mcp.DefaultNumber(listOperationTypesDefaultPages), mcp.Min(1), mcp.Max(listOperationTypesMaxPages)),
+		mcp.WithOutputSchema[operationTypesResult](),
+	)
+
+	s.AddTool(tool, t.handle)
+}
+
+func (t *ToolListOperationTypes) CanBeUsed() bool {
+	return t.client != nil && t.client.IsLoggedIn()
+}
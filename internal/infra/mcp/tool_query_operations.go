@@ -0,0 +1,103 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+	"github.com/tembleking/coverflex-mcp/internal/i18n"
+	"github.com/tembleking/coverflex-mcp/internal/query"
+)
+
+const (
+	queryOperationsDefaultMaxPages = 20
+	queryOperationsMaxPages        = 100
+)
+
+type ToolQueryOperations struct {
+	client   domain.OperationsIterator
+	renderer *i18n.DescriptionRenderer
+}
+
+// queryOperationsResult is the result of the query, and how many operations it ran over.
+type queryOperationsResult struct {
+	query.Result
+	// Scanned is the number of operations the query ran over.
+	Scanned int `json:"scanned"`
+	// Incomplete reports that max_pages was reached, so older operations were left out of the dataset.
+	Incomplete bool `json:"incomplete"`
+}
+
+func NewToolQueryOperations(client domain.OperationsIterator, renderer *i18n.DescriptionRenderer) *ToolQueryOperations {
+	return &ToolQueryOperations{
+		client:   client,
+		renderer: renderer,
+	}
+}
+
+func (t *ToolQueryOperations) handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx = withRequestSource(withRequestLanguage(ctx, request), request)
+	locale := t.client.Locale(ctx)
+	maxPages := min(max(request.GetInt("max_pages", queryOperationsDefaultMaxPages), 1), queryOperationsMaxPages)
+
+	text, err := request.RequireString("query")
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(i18n.T(locale, i18n.ErrInvalidQuery), err), nil
+	}
+	q, err := query.Parse(text)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(i18n.T(locale, i18n.ErrInvalidQuery), err), nil
+	}
+
+	operations, incomplete, err := query.Load(ctx, t.client, maxPages)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(i18n.T(locale, i18n.ErrGetOperations), err), nil
+	}
+	result, err := q.Run(describeOperations(t.renderer, locale, operations))
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(i18n.T(locale, i18n.ErrInvalidQuery), err), nil
+	}
+	return mcp.NewToolResultJSON(queryOperationsResult{Result: *result, Scanned: len(operations), Incomplete: incomplete})
+}
+
+// queryLanguageDescription documents the query language and the fields of an operation.
+func queryLanguageDescription() string {
+	fields := make([]string, 0, len(query.Fields))
+	for _, field := range query.Fields {
+		fields = append(fields, fmt.Sprintf("%s (%s, %s)", field.Name, field.Type, field.Description))
+	}
+	return "A query of the form: [select expr [as name], ...] [where condition] [group by field, ...] [order by expr [asc|desc], ...] [limit n]. " +
+		"Every clause is optional. Aggregates: count(*), count(expr), sum, avg, min, max. " +
+		"Conditions: =, !=, <, <=, >, >=, ~ (contains), !~, in (...), not in (...), and, or, not, parentheses. " +
+		"Strings are quoted and compared ignoring case and accents; numbers compared with money are euros, and 'YYYY-MM-DD' strings compared with dates are dates, while 'YYYY-MM' months stand for all their days, so date >= '2025-03' includes March 1st. " +
+		"Fields: " + strings.Join(fields, "; ") + ". " +
+		"Example: select month, category, sum(amount) as total, count(*) where is_debit and date >= '2025-01-01' group by month, category order by total desc limit 10"
+}
+
+func (t *ToolQueryOperations) RegisterInServer(s *server.MCPServer) {
+	tool := mcp.NewTool("query_operations",
+		mcp.WithDescription("Answer arbitrary questions about the Coverflex operations with a small SQL-like query: filter, group, aggregate, sort and limit them in a single call. The result has typed columns and a row of values per operation or group; money is summed exactly in cents. Debits and credits both have a positive 'amount', use 'signed_amount' or filter on 'is_debit' to net them. Refunds are linked to the payments they refund, so sum(net_amount) over the debits is the spending net of refunds."),
+		mcp.WithString("query",
+			mcp.Required(),
+			mcp.Description(queryLanguageDescription()),
+		),
+		mcp.WithNumber("max_pages",
+			mcp.Description("The maximum number of pages of 50 operations to load, most recent first, before running the query."),
+			mcp.DefaultNumber(queryOperationsDefaultMaxPages),
+			mcp.Min(1),
+			mcp.Max(queryOperationsMaxPages),
+		),
+		withSourceArgument(),
+		withLanguageArgument(),
+		mcp.WithOutputSchema[queryOperationsResult](),
+	)
+
+	s.AddTool(tool, t.handle)
+}
+
+func (t *ToolQueryOperations) CanBeUsed() bool {
+	return t.client != nil && t.client.IsLoggedIn()
+}
@@ -6,37 +6,39 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
-	"github.com/tembleking/coverflex-mcp/internal/infra/coverflex"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+	"github.com/tembleking/coverflex-mcp/internal/i18n"
 )
 
 type ToolRequestOTP struct {
-	coverflexClient *coverflex.Client
+	client domain.Authenticator
 }
 
-func NewToolRequestOTP(client *coverflex.Client) *ToolRequestOTP {
+func NewToolRequestOTP(client domain.Authenticator) *ToolRequestOTP {
 	return &ToolRequestOTP{
-		coverflexClient: client,
+		client: client,
 	}
 }
 
 func (t *ToolRequestOTP) handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	lang := t.client.Locale(ctx)
 	user := os.Getenv("COVERFLEX_USERNAME")
 	pass := os.Getenv("COVERFLEX_PASSWORD")
 
 	if user == "" || pass == "" {
-		return mcp.NewToolResultError("COVERFLEX_USERNAME and COVERFLEX_PASSWORD env vars must be set"), nil
+		return mcp.NewToolResultError(i18n.T(lang, i18n.CredentialsNotSet)), nil
 	}
 
-	if t.coverflexClient.IsLoggedIn() {
-		return mcp.NewToolResultText("already logged in"), nil
+	if t.client.IsLoggedIn() {
+		return mcp.NewToolResultText(i18n.T(lang, i18n.AlreadyLoggedIn)), nil
 	}
 
-	err := t.coverflexClient.RequestOTP(user, pass)
+	err := t.client.RequestOTP(ctx, user, pass)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("error requesting OTP", err), nil
+		return mcp.NewToolResultErrorFromErr(i18n.T(lang, i18n.ErrRequestOTP), err), nil
 	}
 
-	return mcp.NewToolResultText("OTP requested successfully. Please let the user provide the OTP and configure it using the 'trust_device_via_otp' tool."), nil
+	return mcp.NewToolResultText(i18n.T(lang, i18n.OTPRequested)), nil
 }
 
 func (t *ToolRequestOTP) RegisterInServer(s *server.MCPServer) {
@@ -48,5 +50,5 @@ func (t *ToolRequestOTP) RegisterInServer(s *server.MCPServer) {
 }
 
 func (t *ToolRequestOTP) CanBeUsed() bool {
-	return t.coverflexClient != nil && !t.coverflexClient.IsLoggedIn()
+	return t.client != nil && !t.client.IsLoggedIn()
 }
@@ -0,0 +1,181 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+	"github.com/tembleking/coverflex-mcp/internal/i18n"
+	"github.com/tembleking/coverflex-mcp/internal/search"
+)
+
+const (
+	searchOperationsPerPage         = 50
+	searchOperationsDefaultMaxPages = 10
+	searchOperationsMaxPages        = 100
+	searchOperationsMaxLimit        = 50
+)
+
+type ToolSearchOperations struct {
+	client   domain.OperationsIterator
+	renderer *i18n.DescriptionRenderer
+	index    *search.Index
+
+	mu sync.Mutex
+	// depth is the number of most recent pages the index covers, or searchOperationsMaxPages once the whole
+	// history is indexed.
+	depth int
+}
+
+// searchOperationsResult is the ranked hits of a search, with the size of the index they were found in.
+type searchOperationsResult struct {
+	Hits []search.Hit `json:"hits"`
+	// IndexedOperations is the number of operations searched.
+	IndexedOperations int `json:"indexed_operations"`
+	// RefreshError is set when the index could not be refreshed, so recent operations may be missing.
+	RefreshError string `json:"refresh_error,omitempty"`
+}
+
+func NewToolSearchOperations(client domain.OperationsIterator, renderer *i18n.DescriptionRenderer) *ToolSearchOperations {
+	return &ToolSearchOperations{
+		client:   client,
+		renderer: renderer,
+		// The index keeps as many operations as the deepest refresh walks.
+		index: search.NewIndex(search.WithMaxOperations(searchOperationsMaxPages * searchOperationsPerPage)),
+	}
+}
+
+func (t *ToolSearchOperations) handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx = withRequestSource(withRequestLanguage(ctx, request), request)
+	query := search.Query{
+		Text:  request.GetString("query", ""),
+		Limit: min(max(request.GetInt("limit", search.DefaultLimit), 1), searchOperationsMaxLimit),
+	}
+	if amount, err := request.RequireFloat("amount"); err == nil {
+		money := domain.MoneyFromMajor(amount, domain.DefaultCurrency)
+		query.Amount = &money
+	}
+	if tolerance, err := request.RequireFloat("amount_tolerance"); err == nil {
+		money := domain.MoneyFromMajor(tolerance, domain.DefaultCurrency)
+		query.Tolerance = &money
+	}
+	maxPages := min(max(request.GetInt("max_pages", searchOperationsDefaultMaxPages), 1), searchOperationsMaxPages)
+
+	result := searchOperationsResult{}
+	if err := t.refresh(ctx, maxPages); err != nil {
+		if t.index.Len() == 0 {
+			return mcp.NewToolResultErrorFromErr(i18n.T(t.client.Locale(ctx), i18n.ErrGetOperations), err), nil
+		}
+		slog.Warn("Searching a stale operations index", "error", err)
+		result.RefreshError = err.Error()
+	}
+
+	result.Hits = linkHitRefunds(t.index.Search(query), t.index)
+	result.IndexedOperations = t.index.Len()
+	return mcp.NewToolResultJSON(result)
+}
+
+// refresh indexes the most recent operations, walking at most maxPages pages. Once the index covers at
+// least maxPages pages, the walk stops as soon as a whole page worth of operations is already indexed unchanged.
+func (t *ToolSearchOperations) refresh(ctx context.Context, maxPages int) error {
+	t.mu.Lock()
+	covered := t.depth >= maxPages
+	t.mu.Unlock()
+
+	locale := t.client.Locale(ctx)
+	seen, unchanged := 0, 0
+	for operation, err := range t.client.AllOperations(ctx, domain.WithOperationsPerPage(searchOperationsPerPage), domain.WithOperationsMaxPages(maxPages)) {
+		if errors.Is(err, domain.ErrPageLimitReached) {
+			t.covered(maxPages)
+			return nil
+		}
+		if err != nil {
+			t.covered(seen / searchOperationsPerPage)
+			return err
+		}
+		seen++
+		operation.Description = t.renderer.Render(locale, operation.DescriptionTag, operation.Params())
+		if t.index.Add(operation) {
+			unchanged = 0
+		} else {
+			unchanged++
+		}
+		if covered && unchanged >= searchOperationsPerPage {
+			return nil
+		}
+	}
+	// The whole history was walked.
+	t.covered(searchOperationsMaxPages)
+	return nil
+}
+
+// covered records that the index covers the given number of most recent pages.
+func (t *ToolSearchOperations) covered(pages int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.depth = max(t.depth, pages)
+}
+
+// linkHitRefunds links the refunds of the hit operations among the indexed operations close enough in time
+// to be their counterparts.
+func linkHitRefunds(hits []search.Hit, index *search.Index) []search.Hit {
+	if len(hits) == 0 {
+		return hits
+	}
+	windows := make([]domain.DateRange, 0, len(hits))
+	for _, hit := range hits {
+		windows = append(windows, domain.CounterpartWindow(hit.Operation))
+	}
+	linked := make(map[string]domain.Operation)
+	for _, operation := range domain.LinkRefunds(index.OperationsWithin(windows...)) {
+		linked[operation.ID] = operation
+	}
+	for i := range hits {
+		if operation, ok := linked[hits[i].Operation.ID]; ok {
+			hits[i].Operation = operation
+		}
+	}
+	return hits
+}
+
+func (t *ToolSearchOperations) RegisterInServer(s *server.MCPServer) {
+	tool := mcp.NewTool("search_operations",
+		mcp.WithDescription("Search the Coverflex user operations by merchant name, description and amount, e.g. 'pingo doce' or 'uber 23€'. Text matching ignores case and accents and tolerates typos, and amounts match approximately. Returns the best hits first, with the matching fields highlighted between ** markers. The search runs over a local index that is refreshed with the most recent operations on every call."),
+		mcp.WithString("query",
+			mcp.Description("The words to look for in the merchant names and descriptions. Amounts written in the query with a currency or decimals, such as '23€' or '12,50', are matched against the operation amounts; other numbers, such as '2024' or a store code, are searched as text."),
+		),
+		mcp.WithNumber("amount",
+			mcp.Description("An amount in euros to match approximately, regardless of its sign, e.g. 23."),
+			mcp.Min(0),
+		),
+		mcp.WithNumber("amount_tolerance",
+			mcp.Description("How far in euros an amount may be from the searched one. Defaults to 5% of the amount, and at least 1 euro."),
+			mcp.Min(0),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("The maximum number of hits to return."),
+			mcp.DefaultNumber(search.DefaultLimit),
+			mcp.Min(1),
+			mcp.Max(searchOperationsMaxLimit),
+		),
+		mcp.WithNumber("max_pages",
+			mcp.Description("The maximum number of pages of 50 operations to fetch when refreshing the index. Raise it to search older operations."),
+			mcp.DefaultNumber(searchOperationsDefaultMaxPages),
+			mcp.Min(1),
+			mcp.Max(searchOperationsMaxPages),
+		),
+		withSourceArgument(),
+		withLanguageArgument(),
+		mcp.WithOutputSchema[searchOperationsResult](),
+	)
+
+	s.AddTool(tool, t.handle)
+}
+
+func (t *ToolSearchOperations) CanBeUsed() bool {
+	return t.client != nil && t.client.IsLoggedIn()
+}
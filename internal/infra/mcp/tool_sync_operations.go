@@ -0,0 +1,57 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+	"github.com/tembleking/coverflex-mcp/internal/i18n"
+	"github.com/tembleking/coverflex-mcp/internal/ledger"
+)
+
+const syncOperationsDefaultMaxPages = 20
+
+type ToolSyncOperations struct {
+	client domain.OperationsSyncer
+}
+
+func NewToolSyncOperations(client domain.OperationsSyncer) *ToolSyncOperations {
+	return &ToolSyncOperations{
+		client: client,
+	}
+}
+
+func (t *ToolSyncOperations) handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	full := request.GetBool("full", false)
+	maxPages := max(request.GetInt("max_pages", syncOperationsDefaultMaxPages), 0)
+
+	result, err := t.client.SyncOperations(ctx, full, maxPages)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(i18n.T(t.client.Locale(ctx), i18n.ErrSyncOperations), err), nil
+	}
+	return mcp.NewToolResultJSON(result)
+}
+
+func (t *ToolSyncOperations) RegisterInServer(s *server.MCPServer) {
+	tool := mcp.NewTool("sync_operations",
+		mcp.WithDescription(fmt.Sprintf("Sync the local operations ledger with Coverflex, fetching only the pages since the last sync and back to the operations still pending from the last %d days. Reports the new and updated operations and the status transitions detected on known ones, such as a pending operation being confirmed or a payment being reversed. Once synced, the operations tools can read from the ledger with source 'ledger'.", ledger.PendingRecheckDays)),
+		mcp.WithBoolean("full",
+			mcp.Description("Walk the whole history again instead of stopping at the operations already synced."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithNumber("max_pages",
+			mcp.Description("The maximum number of pages of 50 operations to fetch, 0 meaning no limit."),
+			mcp.DefaultNumber(syncOperationsDefaultMaxPages),
+			mcp.Min(0),
+		),
+		mcp.WithOutputSchema[domain.LedgerSyncResult](),
+	)
+
+	s.AddTool(tool, t.handle)
+}
+
+func (t *ToolSyncOperations) CanBeUsed() bool {
+	return t.client != nil && t.client.IsLoggedIn()
+}
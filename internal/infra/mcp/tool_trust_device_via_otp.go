@@ -6,42 +6,44 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
-	"github.com/tembleking/coverflex-mcp/internal/infra/coverflex"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+	"github.com/tembleking/coverflex-mcp/internal/i18n"
 )
 
 type ToolTrustDeviceViaOTP struct {
-	coverflexClient *coverflex.Client
+	client domain.Authenticator
 }
 
-func NewToolTrustDeviceViaOTP(client *coverflex.Client) *ToolTrustDeviceViaOTP {
+func NewToolTrustDeviceViaOTP(client domain.Authenticator) *ToolTrustDeviceViaOTP {
 	return &ToolTrustDeviceViaOTP{
-		coverflexClient: client,
+		client: client,
 	}
 }
 
 func (t *ToolTrustDeviceViaOTP) handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	lang := t.client.Locale(ctx)
 	otp := request.GetString("otp", "")
 	if otp == "" {
-		return mcp.NewToolResultError("otp is required"), nil
+		return mcp.NewToolResultError(i18n.T(lang, i18n.OTPRequired)), nil
 	}
 
 	user := os.Getenv("COVERFLEX_USERNAME")
 	pass := os.Getenv("COVERFLEX_PASSWORD")
 
 	if user == "" || pass == "" {
-		return mcp.NewToolResultError("COVERFLEX_USERNAME and COVERFLEX_PASSWORD env vars must be set"), nil
+		return mcp.NewToolResultError(i18n.T(lang, i18n.CredentialsNotSet)), nil
 	}
 
-	if t.coverflexClient.IsLoggedIn() {
-		return mcp.NewToolResultText("already logged in"), nil
+	if t.client.IsLoggedIn() {
+		return mcp.NewToolResultText(i18n.T(lang, i18n.AlreadyLoggedIn)), nil
 	}
 
-	err := t.coverflexClient.Login(user, pass, otp)
+	err := t.client.Login(ctx, user, pass, otp)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("error submitting OTP", err), nil
+		return mcp.NewToolResultErrorFromErr(i18n.T(lang, i18n.ErrSubmitOTP), err), nil
 	}
 
-	return mcp.NewToolResultText("OTP submitted successfully. Device trusted, refresh the MCP servers to see the available tools."), nil
+	return mcp.NewToolResultText(i18n.T(lang, i18n.OTPSubmitted)), nil
 }
 
 func (t *ToolTrustDeviceViaOTP) RegisterInServer(s *server.MCPServer) {
@@ -54,5 +56,5 @@ func (t *ToolTrustDeviceViaOTP) RegisterInServer(s *server.MCPServer) {
 }
 
 func (t *ToolTrustDeviceViaOTP) CanBeUsed() bool {
-	return t.coverflexClient != nil && !t.coverflexClient.IsLoggedIn()
+	return t.client != nil && !t.client.IsLoggedIn()
 }
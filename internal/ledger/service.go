@@ -0,0 +1,161 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"log/slog"
+	"slices"
+
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+// Sources the operations can be read from.
+const (
+	SourceAPI    = "api"
+	SourceLedger = "ledger"
+)
+
+// Sources lists the operation sources, the default first.
+var Sources = []string{SourceAPI, SourceLedger}
+
+type sourceKey struct{}
+
+// WithSource returns a context whose operations are read from the given source. An empty source keeps the default.
+func WithSource(ctx context.Context, source string) context.Context {
+	if source == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, sourceKey{}, source)
+}
+
+func fromLedger(ctx context.Context) bool {
+	source, _ := ctx.Value(sourceKey{}).(string)
+	return source == SourceLedger
+}
+
+// LookupScanPages is the number of pages of the API walked to find an operation neither its endpoint
+// nor the ledger has.
+const LookupScanPages = 20
+
+// API is the part of the Coverflex API the service decorates.
+type API interface {
+	domain.BenefitsReader
+	domain.OperationReader
+	domain.OperationsReader
+	domain.OperationsCursorReader
+	domain.OperationsIterator
+	domain.CompensationReader
+}
+
+// Service reads the operations from the API or from the ledger, depending on the source set in the
+// context, and syncs the ledger with the API. Every other call goes to the API.
+type Service struct {
+	API
+	store *Store
+}
+
+var (
+	_ domain.OperationsPeriodReader   = (*Service)(nil)
+	_ domain.OperationsPeriodIterator = (*Service)(nil)
+	_ domain.OperationsSyncer         = (*Service)(nil)
+	_ domain.OperationDetailReader    = (*Service)(nil)
+	_ domain.OperationsPagesReader    = (*Service)(nil)
+)
+
+// NewService creates a service reading the operations from the API by default.
+func NewService(api API, store *Store) *Service {
+	return &Service{API: api, store: store}
+}
+
+// GetOperations returns a page of operations, from the ledger if the context asks for it.
+func (s *Service) GetOperations(ctx context.Context, opts ...domain.GetOperationsOption) ([]domain.Operation, error) {
+	if !fromLedger(ctx) {
+		return s.API.GetOperations(ctx, opts...)
+	}
+	operations, err := s.store.Operations()
+	if err != nil {
+		return nil, err
+	}
+	return domain.FilteredOperationsPage(ctx, pagesOf(operations), opts...)
+}
+
+// GetOperationsAfter returns the page of operations after the cursor, from the ledger if the context asks for it.
+func (s *Service) GetOperationsAfter(ctx context.Context, cursor *domain.OperationsCursor, opts ...domain.GetOperationsOption) (*domain.OperationsCursorPage, error) {
+	if !fromLedger(ctx) {
+		return s.API.GetOperationsAfter(ctx, cursor, opts...)
+	}
+	operations, err := s.store.Operations()
+	if err != nil {
+		return nil, err
+	}
+	return domain.OperationsPageAfter(ctx, pagesOf(operations), cursor, opts...)
+}
+
+// AllOperations iterates over the operations, from the ledger if the context asks for it.
+func (s *Service) AllOperations(ctx context.Context, opts ...domain.GetOperationsOption) iter.Seq2[domain.Operation, error] {
+	if !fromLedger(ctx) {
+		return s.API.AllOperations(ctx, opts...)
+	}
+	operations, err := s.store.Operations()
+	if err != nil {
+		return func(yield func(domain.Operation, error) bool) {
+			yield(domain.Operation{}, err)
+		}
+	}
+	return domain.PaginateOperations(ctx, pagesOf(operations), opts...)
+}
+
+// GetOperation returns the operation with the given ID. From the API, it asks the operation endpoint and,
+// when that fails for any reason, looks it up in the ledger and then in the most recent pages. The
+// endpoint is not documented, so a failure other than not finding the operation is logged and tolerated.
+func (s *Service) GetOperation(ctx context.Context, id string) (*domain.Operation, error) {
+	if fromLedger(ctx) {
+		return s.store.Operation(id)
+	}
+
+	operation, err := s.API.GetOperation(ctx, id)
+	if err == nil {
+		return operation, nil
+	}
+	if !errors.Is(err, domain.ErrOperationNotFound) {
+		slog.Warn("Failed to fetch the operation from its endpoint, looking it up elsewhere", "id", id, "error", err)
+	}
+	stored, err := s.store.Operation(id)
+	if err == nil {
+		return stored, nil
+	}
+	if !errors.Is(err, domain.ErrOperationNotFound) {
+		slog.Warn("Failed to look the operation up in the ledger", "id", id, "error", err)
+	}
+
+	slog.Info("Looking the operation up in the recent pages", "id", id, "pages", LookupScanPages)
+	for operation, err := range s.API.AllOperations(ctx, domain.WithOperationsMaxPages(LookupScanPages)) {
+		if errors.Is(err, domain.ErrPageLimitReached) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if operation.ID == id {
+			return &operation, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", domain.ErrOperationNotFound, id)
+}
+
+// SyncOperations fetches the new operations from the API into the ledger.
+func (s *Service) SyncOperations(ctx context.Context, full bool, maxPages int) (*domain.LedgerSyncResult, error) {
+	return s.store.Sync(ctx, s.API, full, maxPages)
+}
+
+// pagesOf serves the operations in pages, as the API does. Filters are applied by the caller.
+func pagesOf(operations []domain.Operation) domain.OperationsPageFetcher {
+	return func(_ context.Context, opts ...domain.GetOperationsOption) ([]domain.Operation, error) {
+		params := domain.NewGetOperationsParams(opts...)
+		start := min((params.Page-1)*params.PerPage, len(operations))
+		end := min(start+params.PerPage, len(operations))
+		return slices.Clone(operations[start:end]), nil
+	}
+}
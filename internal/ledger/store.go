@@ -0,0 +1,145 @@
+// Package ledger keeps a local copy of the operations history, so it can be queried without walking the API.
+package ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+// SyncPageSize is the number of operations requested per page when syncing.
+const SyncPageSize = 50
+
+// PendingRecheckDays is how long after their execution the pending operations in the ledger are walked
+// again by an incremental sync, so it catches them being confirmed or reversed.
+const PendingRecheckDays = 30
+
+// Store reads and syncs the ledger persisted in a repository. The ledger is loaded on every read,
+// so the changes made by another process, such as the sync command, are seen right away.
+type Store struct {
+	mu   sync.Mutex
+	repo domain.LedgerRepository
+}
+
+// NewStore creates a store backed by the given repository.
+func NewStore(repo domain.LedgerRepository) *Store {
+	return &Store{repo: repo}
+}
+
+// Operations returns the operations in the ledger, most recent first, with the refunds linked to their payments.
+func (s *Store) Operations() ([]domain.Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ledger, err := s.repo.Load()
+	if err != nil {
+		return nil, err
+	}
+	if len(ledger.Operations) == 0 {
+		return nil, errors.New("the operations ledger is empty, sync it first")
+	}
+	return domain.LinkRefunds(ledger.Sorted()), nil
+}
+
+// Operation returns the operation with the given ID, with its refund links, or an error wrapping
+// domain.ErrOperationNotFound if the ledger does not have it.
+func (s *Store) Operation(id string) (*domain.Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ledger, err := s.repo.Load()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := ledger.Operations[id]; !ok {
+		return nil, fmt.Errorf("%w in the ledger: %s", domain.ErrOperationNotFound, id)
+	}
+	operations := domain.LinkRefunds(ledger.Sorted())
+	index := slices.IndexFunc(operations, func(operation domain.Operation) bool { return operation.ID == id })
+	return &operations[index], nil
+}
+
+// Sync fetches the operations from the source into the ledger. Unless full is set or the ledger is incomplete,
+// it stops after a whole page of operations already in the ledger unchanged, once it walked past the pending
+// operations of the last PendingRecheckDays. The progress is saved even if a page fails to load.
+func (s *Store) Sync(ctx context.Context, source domain.OperationsIterator, full bool, maxPages int) (*domain.LedgerSyncResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ledger, err := s.repo.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	now := domain.NewTimestamp(time.Now())
+	result := &domain.LedgerSyncResult{
+		Full:        full || !ledger.Complete,
+		Transitions: []domain.StatusTransition{},
+		SyncedAt:    now,
+	}
+	slog.Info("Syncing the operations ledger", "full", result.Full, "known", len(ledger.Operations))
+
+	opts := []domain.GetOperationsOption{domain.WithOperationsPerPage(SyncPageSize)}
+	if maxPages > 0 {
+		opts = append(opts, domain.WithOperationsMaxPages(maxPages))
+	}
+
+	// Operations stay pending for days, so the walk goes on until the ones that may still change were seen.
+	pendingSince := ledger.OldestPending(now.AddDate(0, 0, -PendingRecheckDays))
+
+	// reachedEnd is whether the oldest operation was reached, and caughtUp whether the known operations were.
+	reachedEnd, caughtUp, unchanged := true, false, 0
+	var syncErr error
+	for operation, err := range source.AllOperations(ctx, opts...) {
+		if errors.Is(err, domain.ErrPageLimitReached) {
+			reachedEnd, result.Truncated = false, true
+			break
+		}
+		if err != nil {
+			reachedEnd, syncErr = false, err
+			break
+		}
+
+		result.Fetched++
+		_, known := ledger.Operations[operation.ID]
+		changed, transition := ledger.Upsert(operation, now)
+		switch {
+		case !known:
+			result.New++
+		case changed:
+			result.Updated++
+		}
+		if transition != nil {
+			result.Transitions = append(result.Transitions, *transition)
+		}
+
+		if changed {
+			unchanged = 0
+		} else {
+			unchanged++
+		}
+		pastPending := pendingSince.IsZero() || operation.ExecutedAt.Before(pendingSince.Time)
+		if !result.Full && unchanged >= SyncPageSize && pastPending {
+			reachedEnd, caughtUp = false, true
+			break
+		}
+	}
+
+	// A sync that stops before reaching the known operations leaves a gap only a full sync can fill.
+	ledger.Complete = reachedEnd || caughtUp && ledger.Complete
+	ledger.LastSyncAt = now
+	result.Total = len(ledger.Operations)
+	if err := s.repo.Save(ledger); err != nil {
+		return nil, err
+	}
+	if syncErr != nil {
+		return result, fmt.Errorf("sync stopped after %d operations: %w", result.Fetched, syncErr)
+	}
+	return result, nil
+}
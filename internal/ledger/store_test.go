@@ -0,0 +1,161 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"testing"
+	"time"
+
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+// memoryRepository keeps the ledger in memory.
+type memoryRepository struct {
+	ledger *domain.Ledger
+}
+
+func (r *memoryRepository) Load() (*domain.Ledger, error) {
+	if r.ledger == nil {
+		return domain.NewLedger(), nil
+	}
+	return r.ledger, nil
+}
+
+func (r *memoryRepository) Save(ledger *domain.Ledger) error {
+	r.ledger = ledger
+	return nil
+}
+
+// history is the operations of the account, most recent first, served in pages like the API does.
+type history struct {
+	operations []domain.Operation
+}
+
+func (h *history) IsLoggedIn() bool              { return true }
+func (h *history) Locale(context.Context) string { return "en" }
+
+func (h *history) AllOperations(ctx context.Context, opts ...domain.GetOperationsOption) iter.Seq2[domain.Operation, error] {
+	return domain.PaginateOperations(ctx, func(_ context.Context, opts ...domain.GetOperationsOption) ([]domain.Operation, error) {
+		params := domain.NewGetOperationsParams(opts...)
+		start := min((params.Page-1)*params.PerPage, len(h.operations))
+		return h.operations[start:min(start+params.PerPage, len(h.operations))], nil
+	}, opts...)
+}
+
+// newHistory returns count confirmed operations, one every six hours back from now.
+func newHistory(count int) *history {
+	now := time.Now()
+	h := &history{operations: make([]domain.Operation, count)}
+	for i := range h.operations {
+		h.operations[i] = domain.Operation{
+			ID:         fmt.Sprintf("op%d", i),
+			Amount:     domain.NewMoney(-100, domain.DefaultCurrency),
+			ExecutedAt: domain.NewTimestamp(now.Add(-time.Duration(i) * 6 * time.Hour)),
+			Status:     domain.OperationStatusConfirmed,
+			Type:       domain.OperationTypeCardTransaction,
+			IsDebit:    true,
+		}
+	}
+	return h
+}
+
+func syncLedger(t *testing.T, store *Store, h *history, full bool, maxPages int) *domain.LedgerSyncResult {
+	t.Helper()
+	result, err := store.Sync(t.Context(), h, full, maxPages)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	return result
+}
+
+func TestSyncStopsAfterAPageOfUnchangedOperations(t *testing.T) {
+	h := newHistory(300)
+	repo := &memoryRepository{}
+	store := NewStore(repo)
+
+	first := syncLedger(t, store, h, false, 0)
+	if !first.Full || first.New != 300 || !repo.ledger.Complete {
+		t.Fatalf("first sync = %+v, complete %v, want a full sync of the 300 operations", first, repo.ledger.Complete)
+	}
+
+	// Two operations arrive.
+	arrived := newHistory(2).operations
+	for i := range arrived {
+		arrived[i].ID = fmt.Sprintf("new%d", i)
+		arrived[i].ExecutedAt = domain.NewTimestamp(arrived[i].ExecutedAt.Add(time.Hour))
+	}
+	h.operations = append(arrived, h.operations...)
+
+	second := syncLedger(t, store, h, false, 0)
+	if second.Full || second.New != 2 || second.Fetched != 2+SyncPageSize || second.Total != 302 || !repo.ledger.Complete {
+		t.Errorf("incremental sync = %+v, complete %v, want the 2 new operations and a page of known ones", second, repo.ledger.Complete)
+	}
+}
+
+func TestSyncRechecksRecentPendingOperations(t *testing.T) {
+	h := newHistory(300)
+	// op100 is 25 days old and op240 60 days old, beyond PendingRecheckDays.
+	h.operations[100].Status = domain.OperationStatusPending
+	h.operations[240].Status = domain.OperationStatusPending
+	repo := &memoryRepository{}
+	store := NewStore(repo)
+	syncLedger(t, store, h, false, 0)
+
+	h.operations[100].Status = domain.OperationStatusConfirmed
+	h.operations[240].Status = domain.OperationStatusReversed
+
+	result := syncLedger(t, store, h, false, 0)
+	if result.Fetched != 101+SyncPageSize || result.Updated != 1 {
+		t.Errorf("sync fetched %d and updated %d, want %d fetched past op100 and 1 updated", result.Fetched, result.Updated, 101+SyncPageSize)
+	}
+	if len(result.Transitions) != 1 || result.Transitions[0].OperationID != "op100" || result.Transitions[0].To != domain.OperationStatusConfirmed {
+		t.Errorf("transitions = %+v, want op100 confirmed", result.Transitions)
+	}
+	if status := repo.ledger.Operations["op240"].Status; status != domain.OperationStatusPending {
+		t.Errorf("op240 status = %s, want it left pending until a full sync", status)
+	}
+
+	full := syncLedger(t, store, h, true, 0)
+	if full.Fetched != 300 || len(full.Transitions) != 1 || !full.Transitions[0].Reversal {
+		t.Errorf("full sync = %+v, want the whole history and op240 reversed", full)
+	}
+}
+
+func TestSyncCutShortIsIncomplete(t *testing.T) {
+	h := newHistory(300)
+	repo := &memoryRepository{}
+	store := NewStore(repo)
+
+	truncated := syncLedger(t, store, h, false, 2)
+	if !truncated.Truncated || truncated.Fetched != 2*SyncPageSize || repo.ledger.Complete {
+		t.Fatalf("sync = %+v, complete %v, want two pages and an incomplete ledger", truncated, repo.ledger.Complete)
+	}
+	// The gap left by the page limit is only filled by walking the whole history again.
+	next := syncLedger(t, store, h, false, 0)
+	if !next.Full || next.New != 300-2*SyncPageSize || !repo.ledger.Complete {
+		t.Errorf("next sync = %+v, complete %v, want a full sync filling the gap", next, repo.ledger.Complete)
+	}
+}
+
+func TestOperationsLinksRefunds(t *testing.T) {
+	h := newHistory(2)
+	h.operations[0].IsDebit, h.operations[0].Type, h.operations[0].Amount = false, domain.OperationTypeRefund, domain.NewMoney(100, domain.DefaultCurrency)
+	h.operations[0].DescriptionParams = []domain.DescriptionParam{{Key: "transaction_id", Value: "op1"}}
+	store := NewStore(&memoryRepository{})
+	if _, err := store.Operations(); err == nil {
+		t.Errorf("Operations() of an empty ledger succeeded, want an error")
+	}
+	syncLedger(t, store, h, false, 0)
+
+	operation, err := store.Operation("op1")
+	if err != nil {
+		t.Fatalf("Operation() error = %v", err)
+	}
+	if len(operation.Refunds) != 1 || operation.Refunds[0] != "op0" {
+		t.Errorf("op1 refunds = %v, want op0", operation.Refunds)
+	}
+	if _, err := store.Operation("missing"); err == nil {
+		t.Errorf("Operation(missing) succeeded, want an error")
+	}
+}
@@ -0,0 +1,372 @@
+package query
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+// scope is what an expression is evaluated against: an operation, and for aggregates the group it belongs to.
+type scope struct {
+	operation domain.Operation
+	group     []domain.Operation
+}
+
+// node is an expression of the query, type-checked when parsed.
+type node interface {
+	typ() Type
+	eval(s scope) (Value, error)
+	String() string
+}
+
+type literalNode struct{ value Value }
+
+func (n literalNode) typ() Type                 { return n.value.Type }
+func (n literalNode) eval(scope) (Value, error) { return n.value, nil }
+func (n literalNode) String() string {
+	if n.value.Type == TypeString {
+		return fmt.Sprintf("%q", n.value.String)
+	}
+	return n.value.Text()
+}
+
+type fieldNode struct{ field Field }
+
+func (n fieldNode) typ() Type                   { return n.field.Type }
+func (n fieldNode) eval(s scope) (Value, error) { return n.field.get(s.operation), nil }
+func (n fieldNode) String() string              { return n.field.Name }
+
+// aggregates are the functions computed over a group of operations.
+var aggregates = []string{"count", "sum", "avg", "min", "max"}
+
+// aggregateNode computes a function over the group. A nil argument is count(*).
+type aggregateNode struct {
+	fn  string
+	arg node
+}
+
+func newAggregateNode(fn string, arg node) (aggregateNode, error) {
+	if arg != nil && containsAggregate(arg) {
+		return aggregateNode{}, fmt.Errorf("%s: aggregates cannot be nested", fn)
+	}
+	switch {
+	case arg == nil && fn != "count":
+		return aggregateNode{}, fmt.Errorf("%s needs an argument", fn)
+	case (fn == "sum" || fn == "avg") && arg.typ() != TypeMoney && arg.typ() != TypeNumber:
+		return aggregateNode{}, fmt.Errorf("%s needs a money or number argument, not %s", fn, arg.typ())
+	}
+	return aggregateNode{fn: fn, arg: arg}, nil
+}
+
+func (n aggregateNode) typ() Type {
+	if n.fn == "count" {
+		return TypeNumber
+	}
+	return n.arg.typ()
+}
+
+func (n aggregateNode) String() string {
+	if n.arg == nil {
+		return n.fn + "(*)"
+	}
+	return fmt.Sprintf("%s(%s)", n.fn, n.arg)
+}
+
+func (n aggregateNode) eval(s scope) (Value, error) {
+	if n.arg == nil {
+		return numberValue(float64(len(s.group))), nil
+	}
+
+	values := make([]Value, 0, len(s.group))
+	for _, operation := range s.group {
+		value, err := n.arg.eval(scope{operation: operation})
+		if err != nil {
+			return null, err
+		}
+		if !value.isNull() {
+			values = append(values, value)
+		}
+	}
+
+	switch n.fn {
+	case "count":
+		return numberValue(float64(len(values))), nil
+	case "sum":
+		return sum(values, n.arg.typ())
+	case "avg":
+		if len(values) == 0 {
+			return null, nil
+		}
+		total, err := sum(values, n.arg.typ())
+		if err != nil {
+			return null, err
+		}
+		if total.Type == TypeMoney {
+			total.Money.MinorUnits = int64(math.Round(float64(total.Money.MinorUnits) / float64(len(values))))
+			return total, nil
+		}
+		return numberValue(total.Number / float64(len(values))), nil
+	default:
+		return extreme(values, n.fn == "max")
+	}
+}
+
+// sum adds the values up. Money is summed in minor units, so the result is exact.
+func sum(values []Value, t Type) (Value, error) {
+	if t == TypeNumber {
+		total := 0.0
+		for _, value := range values {
+			total += value.Number
+		}
+		return numberValue(total), nil
+	}
+	amounts := make([]domain.Money, 0, len(values))
+	for _, value := range values {
+		amounts = append(amounts, value.Money)
+	}
+	total, err := domain.SumMoney(amounts...)
+	if err != nil {
+		return null, err
+	}
+	if total.Currency == "" {
+		total.Currency = domain.DefaultCurrency
+	}
+	return moneyValue(total), nil
+}
+
+func extreme(values []Value, greatest bool) (Value, error) {
+	result := null
+	for _, value := range values {
+		c, err := compare(value, result)
+		if err != nil {
+			return null, err
+		}
+		if result.isNull() || greatest && c > 0 || !greatest && c < 0 {
+			result = value
+		}
+	}
+	return result, nil
+}
+
+type notNode struct{ operand node }
+
+func (n notNode) typ() Type      { return TypeBool }
+func (n notNode) String() string { return "not " + n.operand.String() }
+func (n notNode) eval(s scope) (Value, error) {
+	value, err := n.operand.eval(s)
+	if err != nil {
+		return null, err
+	}
+	return boolValue(!value.truthy()), nil
+}
+
+// logicalNode is an and or or of two conditions, evaluated lazily.
+type logicalNode struct {
+	op          string
+	left, right node
+}
+
+func (n logicalNode) typ() Type      { return TypeBool }
+func (n logicalNode) String() string { return fmt.Sprintf("(%s %s %s)", n.left, n.op, n.right) }
+func (n logicalNode) eval(s scope) (Value, error) {
+	left, err := n.left.eval(s)
+	if err != nil {
+		return null, err
+	}
+	if left.truthy() == (n.op == "or") {
+		return boolValue(left.truthy()), nil
+	}
+	right, err := n.right.eval(s)
+	if err != nil {
+		return null, err
+	}
+	return boolValue(right.truthy()), nil
+}
+
+// compareNode compares two values. Strings are compared case and accent-insensitively, and ~ tests whether
+// the left string contains the right one.
+type compareNode struct {
+	op          string
+	left, right node
+}
+
+func newCompareNode(op string, left, right node) (node, error) {
+	if op != "~" && op != "!~" {
+		if literal, ok := right.(literalNode); ok && left.typ() == TypeDate && literal.value.Type == TypeString {
+			return newDateCompareNode(op, left, literal.value.String)
+		}
+		if literal, ok := left.(literalNode); ok && right.typ() == TypeDate && literal.value.Type == TypeString {
+			return newDateCompareNode(mirroredOperators[op], right, literal.value.String)
+		}
+	}
+	left, right, err := unify(left, right)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s %s: %w", left, op, right, err)
+	}
+	if (op == "~" || op == "!~") && (left.typ() != TypeString || right.typ() != TypeString) {
+		return nil, fmt.Errorf("%s %s %s: %s needs strings", left, op, right, op)
+	}
+	return compareNode{op: op, left: left, right: right}, nil
+}
+
+// mirroredOperators turns a comparison around, so its operands can be swapped.
+var mirroredOperators = map[string]string{"=": "=", "!=": "!=", "<>": "<>", "<": ">", "<=": ">=", ">": "<", ">=": "<="}
+
+// newDateCompareNode compares a date with a day or a whole month such as '2025-03', which stands for all
+// its days: date >= '2025-03' starts on its first day, date <= '2025-03' ends on its last one and
+// date = '2025-03' is any day of the month.
+func newDateCompareNode(op string, date node, literal string) (node, error) {
+	days, err := domain.ParseDateRange(literal, literal)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s '%s': %w", date, op, literal, err)
+	}
+	first := literalNode{dateValue(days.From)}
+	last := literalNode{dateValue(days.To)}
+	within := logicalNode{op: "and", left: compareNode{op: ">=", left: date, right: first}, right: compareNode{op: "<=", left: date, right: last}}
+	switch op {
+	case "=":
+		return within, nil
+	case "!=", "<>":
+		return notNode{within}, nil
+	case "<", ">=":
+		return compareNode{op: op, left: date, right: first}, nil
+	default:
+		return compareNode{op: op, left: date, right: last}, nil
+	}
+}
+
+func (n compareNode) typ() Type      { return TypeBool }
+func (n compareNode) String() string { return fmt.Sprintf("%s %s %s", n.left, n.op, n.right) }
+func (n compareNode) eval(s scope) (Value, error) {
+	left, err := n.left.eval(s)
+	if err != nil {
+		return null, err
+	}
+	right, err := n.right.eval(s)
+	if err != nil {
+		return null, err
+	}
+	if n.op == "~" || n.op == "!~" {
+		found := strings.Contains(domain.FoldText(left.String), domain.FoldText(right.String))
+		return boolValue(found == (n.op == "~")), nil
+	}
+
+	c, err := compare(left, right)
+	if err != nil {
+		return null, err
+	}
+	switch n.op {
+	case "=":
+		return boolValue(c == 0), nil
+	case "!=", "<>":
+		return boolValue(c != 0), nil
+	case "<":
+		return boolValue(c < 0), nil
+	case "<=":
+		return boolValue(c <= 0), nil
+	case ">":
+		return boolValue(c > 0), nil
+	default:
+		return boolValue(c >= 0), nil
+	}
+}
+
+// inNode tests whether the value is one of a list of literals.
+type inNode struct {
+	operand node
+	values  []Value
+	negated bool
+}
+
+func (n inNode) typ() Type { return TypeBool }
+func (n inNode) String() string {
+	values := make([]string, 0, len(n.values))
+	for _, value := range n.values {
+		values = append(values, literalNode{value}.String())
+	}
+	op := "in"
+	if n.negated {
+		op = "not in"
+	}
+	return fmt.Sprintf("%s %s (%s)", n.operand, op, strings.Join(values, ", "))
+}
+
+func (n inNode) eval(s scope) (Value, error) {
+	value, err := n.operand.eval(s)
+	if err != nil {
+		return null, err
+	}
+	for _, candidate := range n.values {
+		c, err := compare(value, candidate)
+		if err != nil {
+			return null, err
+		}
+		if c == 0 {
+			return boolValue(!n.negated), nil
+		}
+	}
+	return boolValue(n.negated), nil
+}
+
+// unify converts a literal compared with an expression to the expression type, e.g. 10 to 10 € when
+// compared with an amount, and fails if the types still differ.
+func unify(left, right node) (node, node, error) {
+	var err error
+	if literal, ok := right.(literalNode); ok {
+		if literal.value, err = coerce(literal.value, left.typ()); err != nil {
+			return left, right, err
+		}
+		right = literal
+	}
+	if literal, ok := left.(literalNode); ok {
+		if literal.value, err = coerce(literal.value, right.typ()); err != nil {
+			return left, right, err
+		}
+		left = literal
+	}
+	if left.typ() != right.typ() && left.typ() != TypeNull && right.typ() != TypeNull {
+		return left, right, fmt.Errorf("cannot compare %s with %s", left.typ(), right.typ())
+	}
+	return left, right, nil
+}
+
+func containsAggregate(n node) bool {
+	found := false
+	walk(n, func(n node) {
+		if _, ok := n.(aggregateNode); ok {
+			found = true
+		}
+	})
+	return found
+}
+
+// walk calls visit on the node and its operands. The argument of an aggregate is not visited.
+func walk(n node, visit func(node)) {
+	visit(n)
+	switch n := n.(type) {
+	case notNode:
+		walk(n.operand, visit)
+	case logicalNode:
+		walk(n.left, visit)
+		walk(n.right, visit)
+	case compareNode:
+		walk(n.left, visit)
+		walk(n.right, visit)
+	case inNode:
+		walk(n.operand, visit)
+	}
+}
+
+// checkGrouped fails if the expression reads a field outside an aggregate that is not grouped by.
+func checkGrouped(n node, groupBy []Field) error {
+	var err error
+	walk(n, func(n node) {
+		if field, ok := n.(fieldNode); ok && err == nil && !slices.ContainsFunc(groupBy, func(f Field) bool { return f.Name == field.field.Name }) {
+			err = fmt.Errorf("%s must be grouped by or used in an aggregate", field.field.Name)
+		}
+	})
+	return err
+}
@@ -0,0 +1,70 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+// Field is a column of the operations dataset.
+type Field struct {
+	Name        string `json:"name"`
+	Type        Type   `json:"type"`
+	Description string `json:"description"`
+	get         func(domain.Operation) Value
+}
+
+// Fields lists the columns an operation can be queried by.
+var Fields = []Field{
+	{"id", TypeString, "operation ID", func(o domain.Operation) Value { return stringValue(o.ID) }},
+	{"date", TypeDate, "day the operation was executed on", func(o domain.Operation) Value { return dateValue(o.ExecutedAt.CalendarDate()) }},
+	{"month", TypeString, "month the operation was executed in, e.g. 2025-03", func(o domain.Operation) Value {
+		return stringValue(o.ExecutedAt.InDisplayLocation().Format("2006-01"))
+	}},
+	{"year", TypeNumber, "year the operation was executed in", func(o domain.Operation) Value {
+		return numberValue(float64(o.ExecutedAt.InDisplayLocation().Year()))
+	}},
+	{"weekday", TypeString, "day of the week, e.g. monday", func(o domain.Operation) Value {
+		return stringValue(strings.ToLower(o.ExecutedAt.InDisplayLocation().Weekday().String()))
+	}},
+	{"type", TypeString, "operation type", func(o domain.Operation) Value { return stringValue(string(o.Type)) }},
+	{"status", TypeString, "operation status", func(o domain.Operation) Value { return stringValue(string(o.Status)) }},
+	{"category", TypeString, "benefit category slug", func(o domain.Operation) Value { return stringValue(string(o.CategorySlug)) }},
+	{"product", TypeString, "benefit product slug", func(o domain.Operation) Value { return stringValue(o.ProductSlug) }},
+	{"merchant", TypeString, "merchant name", func(o domain.Operation) Value { return stringValue(o.MerchantName) }},
+	{"description", TypeString, "human-readable description", func(o domain.Operation) Value { return stringValue(o.Description) }},
+	{"description_tag", TypeString, "description template tag", func(o domain.Operation) Value { return stringValue(o.DescriptionTag) }},
+	{"is_debit", TypeBool, "whether money left the account", func(o domain.Operation) Value { return boolValue(o.IsDebit) }},
+	{"amount", TypeMoney, "absolute amount", func(o domain.Operation) Value { return moneyValue(o.Amount.Abs()) }},
+	{"signed_amount", TypeMoney, "amount, negative for debits", func(o domain.Operation) Value { return moneyValue(signedAmount(o)) }},
+	{"net_amount", TypeMoney, "amount net of refunds: a payment minus its refunds, 0 for a linked refund", func(o domain.Operation) Value {
+		return moneyValue(o.NetAmount())
+	}},
+	{"refunded", TypeMoney, "amount refunded of a payment", func(o domain.Operation) Value {
+		if o.RefundedAmount == nil {
+			return moneyValue(domain.NewMoney(0, o.Amount.Currency))
+		}
+		return moneyValue(*o.RefundedAmount)
+	}},
+	{"refund_of", TypeString, "ID of the payment a refund is for", func(o domain.Operation) Value { return stringValue(o.RefundOf) }},
+}
+
+// DefaultColumns are the fields returned when a query without grouping selects nothing.
+var DefaultColumns = []string{"date", "type", "status", "category", "merchant", "description", "amount", "is_debit", "id"}
+
+func lookupField(name string) (Field, error) {
+	for _, field := range Fields {
+		if strings.EqualFold(field.Name, name) {
+			return field, nil
+		}
+	}
+	return Field{}, fmt.Errorf("unknown field %q", name)
+}
+
+func signedAmount(operation domain.Operation) domain.Money {
+	if operation.IsDebit {
+		return operation.Amount.Abs().Neg()
+	}
+	return operation.Amount.Abs()
+}
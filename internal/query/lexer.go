@@ -0,0 +1,99 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenSymbol
+)
+
+// token is a lexeme of the query, with its byte offset for error messages.
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// is reports whether the token is the given symbol or keyword. Keywords are case-insensitive.
+func (t token) is(text string) bool {
+	switch t.kind {
+	case tokenSymbol:
+		return t.text == text
+	case tokenIdent:
+		return strings.EqualFold(t.text, text)
+	default:
+		return false
+	}
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of query"
+	case tokenString:
+		return fmt.Sprintf("string %q", t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+// symbols are the operators and punctuation, the longest first so "<=" wins over "<".
+var symbols = []string{"!=", "<>", "<=", ">=", "!~", "=", "<", ">", "~", "(", ")", ",", "*", "-"}
+
+// lex splits the query into tokens. Strings are quoted with single or double quotes.
+func lex(input string) ([]token, error) {
+	var tokens []token
+	for pos := 0; pos < len(input); {
+		r := rune(input[pos])
+		switch {
+		case unicode.IsSpace(r):
+			pos++
+		case r == '\'' || r == '"':
+			end := strings.IndexRune(input[pos+1:], r)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string at position %d", pos)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: input[pos+1 : pos+1+end], pos: pos})
+			pos += end + 2
+		case isDigit(r):
+			start := pos
+			for pos < len(input) && (isDigit(rune(input[pos])) || input[pos] == '.') {
+				pos++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: input[start:pos], pos: start})
+		case r == '_' || unicode.IsLetter(r):
+			start := pos
+			for pos < len(input) && (input[pos] == '_' || isDigit(rune(input[pos])) || unicode.IsLetter(rune(input[pos]))) {
+				pos++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: input[start:pos], pos: start})
+		default:
+			symbol := ""
+			for _, candidate := range symbols {
+				if strings.HasPrefix(input[pos:], candidate) {
+					symbol = candidate
+					break
+				}
+			}
+			if symbol == "" {
+				return nil, fmt.Errorf("unexpected character %q at position %d", r, pos)
+			}
+			tokens = append(tokens, token{kind: tokenSymbol, text: symbol, pos: pos})
+			pos += len(symbol)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(input)}), nil
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
@@ -0,0 +1,32 @@
+package query
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+// LoadPageSize is the number of operations requested per page when loading the dataset.
+const LoadPageSize = 50
+
+// Load reads the operations a query runs over, walking at most maxPages pages, 0 meaning no limit, and links
+// the refunds to their payments. It reports whether the page limit left older operations out.
+func Load(ctx context.Context, source domain.OperationsIterator, maxPages int) ([]domain.Operation, bool, error) {
+	opts := []domain.GetOperationsOption{domain.WithOperationsPerPage(LoadPageSize)}
+	if maxPages > 0 {
+		opts = append(opts, domain.WithOperationsMaxPages(maxPages))
+	}
+
+	var operations []domain.Operation
+	for operation, err := range source.AllOperations(ctx, opts...) {
+		if errors.Is(err, domain.ErrPageLimitReached) {
+			return domain.LinkRefunds(operations), true, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		operations = append(operations, operation)
+	}
+	return domain.LinkRefunds(operations), false, nil
+}
@@ -0,0 +1,411 @@
+package query
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Limits of the rows a query returns.
+const (
+	DefaultLimit = 100
+	MaxLimit     = 1000
+)
+
+// column is an expression of the select clause, named by its alias or its text.
+type column struct {
+	name string
+	expr node
+}
+
+// ordering is an expression of the order by clause. Column is the select column it names, or -1.
+type ordering struct {
+	expr   node
+	column int
+	desc   bool
+}
+
+// Query is a parsed query over the operations, ready to run.
+type Query struct {
+	columns []column
+	where   node
+	groupBy []Field
+	orderBy []ordering
+	limit   int
+	// grouped is set when the query groups by fields or selects aggregates, so each row is a group.
+	grouped bool
+}
+
+// Parse parses and type-checks a query of the form
+//
+//	[select expr [as name], ...] [where condition] [group by field, ...] [order by expr [asc|desc], ...] [limit n]
+//
+// Every clause is optional; without select, the default columns are returned, or the grouped fields and
+// count(*) when grouping.
+func Parse(text string) (*Query, error) {
+	tokens, err := lex(text)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	query, err := p.parseQuery()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokenEOF {
+		return nil, unexpected(p.peek())
+	}
+	return query, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the given keywords or symbols if they come next.
+func (p *parser) accept(words ...string) bool {
+	for offset, word := range words {
+		if p.pos+offset >= len(p.tokens) || !p.tokens[p.pos+offset].is(word) {
+			return false
+		}
+	}
+	p.pos += len(words)
+	return true
+}
+
+func (p *parser) expect(words ...string) error {
+	if !p.accept(words...) {
+		return fmt.Errorf("expected %q, found %s", strings.Join(words, " "), p.peek())
+	}
+	return nil
+}
+
+func unexpected(t token) error {
+	return fmt.Errorf("unexpected %s at position %d", t, t.pos)
+}
+
+func (p *parser) parseQuery() (*Query, error) {
+	query := &Query{limit: DefaultLimit}
+	var err error
+
+	if p.accept("select") {
+		if query.columns, err = p.parseColumns(); err != nil {
+			return nil, err
+		}
+	}
+	if p.accept("where") {
+		if query.where, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+		if containsAggregate(query.where) {
+			return nil, fmt.Errorf("where: aggregates are not allowed, filter the operations before grouping")
+		}
+		if query.where.typ() != TypeBool {
+			return nil, fmt.Errorf("where: %s is not a condition", query.where)
+		}
+	}
+	if p.accept("group", "by") {
+		if query.groupBy, err = p.parseGroupBy(); err != nil {
+			return nil, err
+		}
+	}
+	if err := query.resolveColumns(); err != nil {
+		return nil, err
+	}
+	if p.accept("order", "by") {
+		if query.orderBy, err = p.parseOrderBy(query); err != nil {
+			return nil, err
+		}
+	}
+	if p.accept("limit") {
+		t := p.next()
+		limit, err := strconv.Atoi(t.text)
+		if t.kind != tokenNumber || err != nil || limit < 1 {
+			return nil, fmt.Errorf("limit: expected a positive number, found %s", t)
+		}
+		query.limit = min(limit, MaxLimit)
+	}
+	return query, nil
+}
+
+// resolveColumns fills in the default columns and checks every column can be computed for a group.
+func (q *Query) resolveColumns() error {
+	q.grouped = len(q.groupBy) > 0 || slices.ContainsFunc(q.columns, func(c column) bool { return containsAggregate(c.expr) })
+
+	if len(q.columns) == 0 {
+		if q.grouped {
+			for _, field := range q.groupBy {
+				q.columns = append(q.columns, column{name: field.Name, expr: fieldNode{field}})
+			}
+			q.columns = append(q.columns, column{name: "count", expr: aggregateNode{fn: "count"}})
+		} else {
+			for _, name := range DefaultColumns {
+				field, _ := lookupField(name)
+				q.columns = append(q.columns, column{name: field.Name, expr: fieldNode{field}})
+			}
+		}
+	}
+	if !q.grouped {
+		return nil
+	}
+	for _, c := range q.columns {
+		if err := checkGrouped(c.expr, q.groupBy); err != nil {
+			return fmt.Errorf("select %s: %w", c.name, err)
+		}
+	}
+	return nil
+}
+
+func (p *parser) parseColumns() ([]column, error) {
+	if p.accept("*") {
+		columns := make([]column, 0, len(Fields))
+		for _, field := range Fields {
+			columns = append(columns, column{name: field.Name, expr: fieldNode{field}})
+		}
+		return columns, nil
+	}
+
+	var columns []column
+	for {
+		expr, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		name := expr.String()
+		if p.accept("as") {
+			alias := p.next()
+			if alias.kind != tokenIdent && alias.kind != tokenString {
+				return nil, fmt.Errorf("as: expected a column name, found %s", alias)
+			}
+			name = alias.text
+		}
+		if slices.ContainsFunc(columns, func(c column) bool { return c.name == name }) {
+			return nil, fmt.Errorf("select: duplicate column %q, name it with as", name)
+		}
+		columns = append(columns, column{name: name, expr: expr})
+		if !p.accept(",") {
+			return columns, nil
+		}
+	}
+}
+
+func (p *parser) parseGroupBy() ([]Field, error) {
+	var fields []Field
+	for {
+		t := p.next()
+		if t.kind != tokenIdent {
+			return nil, fmt.Errorf("group by: expected a field, found %s", t)
+		}
+		field, err := lookupField(t.text)
+		if err != nil {
+			return nil, fmt.Errorf("group by: %w", err)
+		}
+		fields = append(fields, field)
+		if !p.accept(",") {
+			return fields, nil
+		}
+	}
+}
+
+// parseOrderBy parses the orderings, which may name a select column or be an expression of their own.
+func (p *parser) parseOrderBy(query *Query) ([]ordering, error) {
+	var orderings []ordering
+	for {
+		order := ordering{column: -1}
+		if t := p.peek(); (t.kind == tokenIdent || t.kind == tokenString) && !p.tokens[p.pos+1].is("(") {
+			order.column = slices.IndexFunc(query.columns, func(c column) bool { return c.name == t.text })
+		}
+		if order.column >= 0 {
+			p.next()
+		} else {
+			expr, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			order.column = slices.IndexFunc(query.columns, func(c column) bool { return c.name == expr.String() })
+			if order.column < 0 && query.grouped {
+				if err := checkGrouped(expr, query.groupBy); err != nil {
+					return nil, fmt.Errorf("order by %s: %w", expr, err)
+				}
+			}
+			if order.column < 0 && !query.grouped && containsAggregate(expr) {
+				return nil, fmt.Errorf("order by %s: aggregates need a select aggregate or group by", expr)
+			}
+			order.expr = expr
+		}
+		if p.accept("desc") {
+			order.desc = true
+		} else {
+			p.accept("asc")
+		}
+		orderings = append(orderings, order)
+		if !p.accept(",") {
+			return orderings, nil
+		}
+	}
+}
+
+// parseExpr parses a condition or value: or binds looser than and, which binds looser than not.
+func (p *parser) parseExpr() (node, error) {
+	return p.parseLogical("or", p.parseAnd)
+}
+
+func (p *parser) parseAnd() (node, error) {
+	return p.parseLogical("and", p.parseNot)
+}
+
+func (p *parser) parseLogical(op string, operand func() (node, error)) (node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept(op) {
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		for _, side := range []node{left, right} {
+			if side.typ() != TypeBool {
+				return nil, fmt.Errorf("%s: %s is not a condition", op, side)
+			}
+		}
+		left = logicalNode{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (node, error) {
+	if !p.accept("not") {
+		return p.parseComparison()
+	}
+	operand, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	if operand.typ() != TypeBool {
+		return nil, fmt.Errorf("not: %s is not a condition", operand)
+	}
+	return notNode{operand}, nil
+}
+
+var comparisonOperators = []string{"=", "!=", "<>", "<=", ">=", "<", ">", "~", "!~"}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := p.peek(); {
+	case t.kind == tokenSymbol && slices.Contains(comparisonOperators, t.text):
+		p.next()
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		return newCompareNode(t.text, left, right)
+	case t.is("in"), t.is("not") && p.tokens[p.pos+1].is("in"):
+		negated := p.accept("not")
+		p.next()
+		return p.parseIn(left, negated)
+	}
+	return left, nil
+}
+
+func (p *parser) parseIn(operand node, negated bool) (node, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	in := inNode{operand: operand, negated: negated}
+	for {
+		value, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		literal, ok := value.(literalNode)
+		if !ok {
+			return nil, fmt.Errorf("in: %s is not a literal", value)
+		}
+		_, right, err := unify(operand, literal)
+		if err != nil {
+			return nil, fmt.Errorf("in: %w", err)
+		}
+		in.values = append(in.values, right.(literalNode).value)
+		if !p.accept(",") {
+			break
+		}
+	}
+	return in, p.expect(")")
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch {
+	case t.is("("):
+		expr, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		return expr, p.expect(")")
+	case t.is("-"):
+		number := p.next()
+		value, err := strconv.ParseFloat(number.text, 64)
+		if number.kind != tokenNumber || err != nil {
+			return nil, fmt.Errorf("expected a number after -, found %s", number)
+		}
+		return literalNode{numberValue(-value)}, nil
+	case t.kind == tokenNumber:
+		value, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", t.text)
+		}
+		return literalNode{numberValue(value)}, nil
+	case t.kind == tokenString:
+		return literalNode{stringValue(t.text)}, nil
+	case t.is("true"), t.is("false"):
+		return literalNode{boolValue(t.is("true"))}, nil
+	case t.is("null"):
+		return literalNode{null}, nil
+	case t.kind == tokenIdent && p.peek().is("("):
+		return p.parseAggregate(strings.ToLower(t.text))
+	case t.kind == tokenIdent:
+		field, err := lookupField(t.text)
+		if err != nil {
+			return nil, err
+		}
+		return fieldNode{field}, nil
+	}
+	return nil, unexpected(t)
+}
+
+func (p *parser) parseAggregate(fn string) (node, error) {
+	if !slices.Contains(aggregates, fn) {
+		return nil, fmt.Errorf("unknown function %q, expected one of %s", fn, strings.Join(aggregates, ", "))
+	}
+	p.next()
+	var arg node
+	if !p.accept("*") && !p.peek().is(")") {
+		var err error
+		if arg, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	return newAggregateNode(fn, arg)
+}
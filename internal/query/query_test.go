@@ -0,0 +1,246 @@
+package query
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+func operation(id, day string, category domain.CategorySlug, merchant string, cents int64, debit bool) domain.Operation {
+	executedAt, err := time.Parse(time.DateOnly, day)
+	if err != nil {
+		panic(err)
+	}
+	return domain.Operation{
+		ID:           id,
+		Amount:       domain.NewMoney(cents, domain.DefaultCurrency),
+		CategorySlug: category,
+		MerchantName: merchant,
+		ExecutedAt:   domain.NewTimestamp(executedAt.Add(12 * time.Hour)),
+		IsDebit:      debit,
+		Status:       domain.OperationStatusConfirmed,
+		Type:         domain.OperationTypeCardTransaction,
+	}
+}
+
+var operations = []domain.Operation{
+	operation("1", "2025-03-10", domain.CategoryMeal, "Pingo Doce", 1250, true),
+	operation("2", "2025-03-05", domain.CategoryHealth, "Farmácia Central", 3000, true),
+	operation("3", "2025-02-28", domain.CategoryMeal, "Continente", 875, true),
+	operation("4", "2025-02-01", domain.CategoryMeal, "", 16000, false),
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"select", "unexpected end"},
+		{"where amount", "is not a condition"},
+		{"where sum(amount) > 10", "aggregates are not allowed"},
+		{"where amount > 10 and merchant", "is not a condition"},
+		{"where not merchant", "is not a condition"},
+		{"select nope", `unknown field "nope"`},
+		{"select median(amount)", `unknown function "median"`},
+		{"select merchant, count(*) group by category", "select merchant"},
+		{"group by 12", "group by: expected a field"},
+		{"select amount, amount", "duplicate column"},
+		{"order by sum(amount)", "aggregates need a select aggregate or group by"},
+		{"limit 0", "limit: expected a positive number"},
+		{"limit many", "limit: expected a positive number"},
+		{"where merchant = 'pingo", "unterminated string"},
+		{"where amount > 10 10", "unexpected"},
+		{"where amount in (merchant)", "in: merchant is not a literal"},
+		{"where date > 'yesterday'", "date"},
+		{"where amount ? 10", "unexpected character"},
+		{"where date in ('2025-03')", "invalid date"},
+		{"where date ~ '2025-03'", "date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := Parse(tt.query)
+			if err == nil {
+				t.Fatalf("Parse(%q) succeeded, want an error containing %q", tt.query, tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse(%q) error = %q, want it to contain %q", tt.query, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoerce(t *testing.T) {
+	march10, _ := domain.ParseDate("2025-03-10")
+	tests := []struct {
+		name    string
+		literal Value
+		to      Type
+		want    Value
+		wantErr bool
+	}{
+		{"number to euros", numberValue(12.5), TypeMoney, moneyValue(domain.NewMoney(1250, "EUR")), false},
+		{"number to euros rounds to cents", numberValue(0.105), TypeMoney, moneyValue(domain.NewMoney(11, "EUR")), false},
+		{"string to date", stringValue("2025-03-10"), TypeDate, dateValue(march10), false},
+		{"invalid date", stringValue("10/03/2025"), TypeDate, null, true},
+		{"string to number", stringValue("2025"), TypeNumber, numberValue(2025), false},
+		{"invalid number", stringValue("many"), TypeNumber, null, true},
+		{"same type", stringValue("meal"), TypeString, stringValue("meal"), false},
+		{"null stays null", null, TypeMoney, null, false},
+		{"other types are kept", boolValue(true), TypeMoney, boolValue(true), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := coerce(tt.literal, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("coerce() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("coerce() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    Value
+		want    int
+		wantErr bool
+	}{
+		{"money with a number literal", moneyValue(domain.NewMoney(1250, "EUR")), numberValue(12.5), 0, false},
+		{"strings ignore case and accents", stringValue("Farmácia"), stringValue("FARMACIA"), 0, false},
+		{"nulls come first", null, numberValue(1), -1, false},
+		{"dates with a string literal", dateValue(domain.Date{}), stringValue("2025-01-01"), -1, false},
+		{"incompatible types", boolValue(true), moneyValue(domain.NewMoney(1, "EUR")), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := compare(tt.a, tt.b)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("compare() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("compare() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		columns   []string
+		rows      [][]string
+		matched   int
+		truncated bool
+	}{
+		{
+			name:    "group by with the default columns",
+			query:   "where is_debit group by category order by category",
+			columns: []string{"category", "count"},
+			rows:    [][]string{{"health", "1"}, {"meal", "2"}},
+			matched: 3,
+		},
+		{
+			name:    "group by with aggregates",
+			query:   "select category, sum(amount) as total, max(amount) as largest group by category order by total desc",
+			columns: []string{"category", "total", "largest"},
+			rows:    [][]string{{"meal", "181,25 €", "160,00 €"}, {"health", "30,00 €", "30,00 €"}},
+			matched: 4,
+		},
+		{
+			name:    "group by several fields keeps the first operation order",
+			query:   "select month, category, count(*) as n where is_debit group by month, category",
+			columns: []string{"month", "category", "n"},
+			rows:    [][]string{{"2025-03", "meal", "1"}, {"2025-03", "health", "1"}, {"2025-02", "meal", "1"}},
+			matched: 3,
+		},
+		{
+			name:    "aggregates without group by make a single group",
+			query:   "select count(*), avg(amount) where merchant ~ 'pingo' or merchant ~ 'continente'",
+			columns: []string{"count(*)", "avg(amount)"},
+			rows:    [][]string{{"2", "10,63 €"}},
+			matched: 2,
+		},
+		{
+			name:    "aggregates over no operations",
+			query:   "select count(*) where amount > 1000",
+			columns: []string{"count(*)"},
+			rows:    [][]string{{"0"}},
+		},
+		{
+			name:      "amounts compare with euros and the limit truncates",
+			query:     "select id where amount >= 12.5 and date >= '2025-03-01' order by amount limit 1",
+			columns:   []string{"id"},
+			rows:      [][]string{{"1"}},
+			matched:   2,
+			truncated: true,
+		},
+		{
+			name:    "a month literal starts on its first day",
+			query:   "select id where date >= '2025-03' order by id",
+			columns: []string{"id"},
+			rows:    [][]string{{"1"}, {"2"}},
+			matched: 2,
+		},
+		{
+			name:    "a month literal equals any of its days",
+			query:   "select id where date = '2025-02' order by id",
+			columns: []string{"id"},
+			rows:    [][]string{{"3"}, {"4"}},
+			matched: 2,
+		},
+		{
+			name:    "a month literal on the left ends on its last day",
+			query:   "select id where '2025-02' < date and date != '2025-04' order by id",
+			columns: []string{"id"},
+			rows:    [][]string{{"1"}, {"2"}},
+			matched: 2,
+		},
+		{
+			name:    "in coerces the values",
+			query:   "select id where date in ('2025-02-01', '2025-03-10') order by id",
+			columns: []string{"id"},
+			rows:    [][]string{{"1"}, {"4"}},
+			matched: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.query, err)
+			}
+			result, err := query.Run(operations)
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			columns := make([]string, 0, len(result.Columns))
+			for _, column := range result.Columns {
+				columns = append(columns, column.Name)
+			}
+			if !slices.Equal(columns, tt.columns) {
+				t.Errorf("columns = %v, want %v", columns, tt.columns)
+			}
+			rows := make([][]string, 0, len(result.Rows))
+			for _, row := range result.Rows {
+				texts := make([]string, 0, len(row))
+				for _, value := range row {
+					texts = append(texts, value.Text())
+				}
+				rows = append(rows, texts)
+			}
+			if !slices.EqualFunc(rows, tt.rows, slices.Equal) {
+				t.Errorf("rows = %v, want %v", rows, tt.rows)
+			}
+			if result.Matched != tt.matched || result.Truncated != tt.truncated {
+				t.Errorf("matched, truncated = %d, %v, want %d, %v", result.Matched, result.Truncated, tt.matched, tt.truncated)
+			}
+		})
+	}
+}
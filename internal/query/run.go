@@ -0,0 +1,146 @@
+// Package query runs a small SQL-like language over the operations, so arbitrary spending questions
+// can be answered in one call with exact arithmetic.
+package query
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+// Column is a column of a query result.
+type Column struct {
+	Name string `json:"name"`
+	Type Type   `json:"type"`
+}
+
+// Result is the outcome of a query: the typed columns, and the rows with a value per column.
+type Result struct {
+	Columns []Column  `json:"columns"`
+	Rows    [][]Value `json:"rows"`
+	// Matched is the number of operations that passed the where clause.
+	Matched int `json:"matched"`
+	// Truncated reports whether the limit left rows out.
+	Truncated bool `json:"truncated"`
+}
+
+// row is a result row, with the scope it was computed from so the orderings can be evaluated.
+type row struct {
+	scope  scope
+	values []Value
+	keys   []Value
+}
+
+// Run runs the query over the operations.
+func (q *Query) Run(operations []domain.Operation) (*Result, error) {
+	matched := make([]domain.Operation, 0, len(operations))
+	for _, operation := range operations {
+		if q.where != nil {
+			value, err := q.where.eval(scope{operation: operation})
+			if err != nil {
+				return nil, err
+			}
+			if !value.truthy() {
+				continue
+			}
+		}
+		matched = append(matched, operation)
+	}
+
+	result := &Result{Columns: make([]Column, 0, len(q.columns)), Rows: [][]Value{}, Matched: len(matched)}
+	for _, c := range q.columns {
+		result.Columns = append(result.Columns, Column{Name: c.name, Type: c.expr.typ()})
+	}
+
+	rows := make([]row, 0, len(matched))
+	for _, s := range q.scopes(matched) {
+		r := row{scope: s}
+		for _, c := range q.columns {
+			value, err := c.expr.eval(s)
+			if err != nil {
+				return nil, err
+			}
+			r.values = append(r.values, value)
+		}
+		for _, order := range q.orderBy {
+			if order.column >= 0 {
+				r.keys = append(r.keys, r.values[order.column])
+				continue
+			}
+			value, err := order.expr.eval(s)
+			if err != nil {
+				return nil, err
+			}
+			r.keys = append(r.keys, value)
+		}
+		rows = append(rows, r)
+	}
+
+	var sortErr error
+	slices.SortStableFunc(rows, func(a, b row) int {
+		for index, order := range q.orderBy {
+			c, err := compare(a.keys[index], b.keys[index])
+			if err != nil && sortErr == nil {
+				sortErr = err
+			}
+			if order.desc {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+
+	result.Truncated = len(rows) > q.limit
+	for _, r := range rows[:min(len(rows), q.limit)] {
+		result.Rows = append(result.Rows, r.values)
+	}
+	return result, nil
+}
+
+// scopes returns a scope per operation, or per group when the query groups. Groups keep the order of their
+// first operation, and a query aggregating without group by has a single group, even if empty.
+func (q *Query) scopes(operations []domain.Operation) []scope {
+	if !q.grouped {
+		scopes := make([]scope, 0, len(operations))
+		for _, operation := range operations {
+			scopes = append(scopes, scope{operation: operation, group: []domain.Operation{operation}})
+		}
+		return scopes
+	}
+	if len(q.groupBy) == 0 {
+		s := scope{group: operations}
+		if len(operations) > 0 {
+			s.operation = operations[0]
+		}
+		return []scope{s}
+	}
+
+	var scopes []scope
+	index := make(map[string]int)
+	for _, operation := range operations {
+		key := q.groupKey(operation)
+		position, ok := index[key]
+		if !ok {
+			position = len(scopes)
+			index[key] = position
+			scopes = append(scopes, scope{operation: operation})
+		}
+		scopes[position].group = append(scopes[position].group, operation)
+	}
+	return scopes
+}
+
+func (q *Query) groupKey(operation domain.Operation) string {
+	parts := make([]string, 0, len(q.groupBy))
+	for _, field := range q.groupBy {
+		parts = append(parts, field.get(operation).Text())
+	}
+	return strings.Join(parts, "\x00")
+}
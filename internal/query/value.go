@@ -0,0 +1,176 @@
+package query
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+// Type is the type of a value or a result column.
+type Type string
+
+// Value types.
+const (
+	TypeNull   Type = "null"
+	TypeString Type = "string"
+	TypeNumber Type = "number"
+	TypeBool   Type = "bool"
+	TypeMoney  Type = "money"
+	TypeDate   Type = "date"
+)
+
+// Value is a typed value of the query language. Amounts are kept as domain.Money, so sums are exact.
+type Value struct {
+	Type   Type
+	String string
+	Number float64
+	Bool   bool
+	Money  domain.Money
+	Date   domain.Date
+}
+
+var null = Value{Type: TypeNull}
+
+func stringValue(s string) Value      { return Value{Type: TypeString, String: s} }
+func numberValue(n float64) Value     { return Value{Type: TypeNumber, Number: n} }
+func boolValue(b bool) Value          { return Value{Type: TypeBool, Bool: b} }
+func moneyValue(m domain.Money) Value { return Value{Type: TypeMoney, Money: m} }
+func dateValue(d domain.Date) Value   { return Value{Type: TypeDate, Date: d} }
+
+func (v Value) isNull() bool { return v.Type == TypeNull }
+
+// MarshalJSON encodes the value as its natural JSON form: money and dates use their domain representation.
+func (v Value) MarshalJSON() ([]byte, error) {
+	switch v.Type {
+	case TypeString:
+		return json.Marshal(v.String)
+	case TypeNumber:
+		return json.Marshal(v.Number)
+	case TypeBool:
+		return json.Marshal(v.Bool)
+	case TypeMoney:
+		return json.Marshal(v.Money)
+	case TypeDate:
+		return json.Marshal(v.Date)
+	default:
+		return []byte("null"), nil
+	}
+}
+
+// JSONSchema describes the JSON produced by MarshalJSON: the column type tells which form a value takes.
+func (Value) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Description: "Value of the column, in the form of its type: money and date values are objects.",
+		AnyOf: []*jsonschema.Schema{
+			{Type: "string"},
+			{Type: "number"},
+			{Type: "boolean"},
+			{Type: "null"},
+			domain.Money{}.JSONSchema(),
+			domain.Date{}.JSONSchema(),
+		},
+	}
+}
+
+// Text returns the value formatted for display in a table.
+func (v Value) Text() string {
+	switch v.Type {
+	case TypeString:
+		return v.String
+	case TypeNumber:
+		return strconv.FormatFloat(v.Number, 'f', -1, 64)
+	case TypeBool:
+		return strconv.FormatBool(v.Bool)
+	case TypeMoney:
+		return v.Money.String()
+	case TypeDate:
+		return v.Date.ISO()
+	default:
+		return ""
+	}
+}
+
+// truthy reports whether the value passes a where clause.
+func (v Value) truthy() bool {
+	switch v.Type {
+	case TypeBool:
+		return v.Bool
+	case TypeNull:
+		return false
+	default:
+		return true
+	}
+}
+
+// coerce converts a literal to the type of the value it is compared with: numbers to euros, and strings to dates.
+func coerce(literal Value, to Type) (Value, error) {
+	switch {
+	case literal.Type == to || literal.isNull():
+		return literal, nil
+	case to == TypeMoney && literal.Type == TypeNumber:
+		return moneyValue(domain.MoneyFromMajor(literal.Number, domain.DefaultCurrency)), nil
+	case to == TypeDate && literal.Type == TypeString:
+		date, err := domain.ParseDate(literal.String)
+		if err != nil {
+			return null, err
+		}
+		return dateValue(date), nil
+	case to == TypeNumber && literal.Type == TypeString:
+		number, err := strconv.ParseFloat(literal.String, 64)
+		if err != nil {
+			return null, fmt.Errorf("%q is not a number", literal.String)
+		}
+		return numberValue(number), nil
+	}
+	return literal, nil
+}
+
+// compare orders two values of the same type, after coercing literals. Nulls come first.
+func compare(a, b Value) (int, error) {
+	if a.isNull() || b.isNull() {
+		switch {
+		case a.isNull() && b.isNull():
+			return 0, nil
+		case a.isNull():
+			return -1, nil
+		default:
+			return 1, nil
+		}
+	}
+	var err error
+	if b, err = coerce(b, a.Type); err != nil {
+		return 0, err
+	}
+	if a, err = coerce(a, b.Type); err != nil {
+		return 0, err
+	}
+	if a.Type != b.Type {
+		return 0, fmt.Errorf("cannot compare %s with %s", a.Type, b.Type)
+	}
+
+	switch a.Type {
+	case TypeString:
+		return strings.Compare(domain.FoldText(a.String), domain.FoldText(b.String)), nil
+	case TypeNumber:
+		return cmp.Compare(a.Number, b.Number), nil
+	case TypeBool:
+		return cmp.Compare(boolNumber(a.Bool), boolNumber(b.Bool)), nil
+	case TypeMoney:
+		return a.Money.Cmp(b.Money)
+	case TypeDate:
+		return a.Date.Compare(b.Date), nil
+	}
+	return 0, nil
+}
+
+func boolNumber(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
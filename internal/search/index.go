@@ -0,0 +1,348 @@
+// Package search provides accent- and case-insensitive fuzzy search over operations.
+package search
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+// Searchable fields of an operation.
+const (
+	FieldMerchant    = "merchant_name"
+	FieldDescription = "description"
+	FieldAmount      = "amount"
+)
+
+// fieldWeights is how much a match in each text field is worth. Merchant names are the most specific.
+var fieldWeights = map[string]float64{
+	FieldMerchant:    1,
+	FieldDescription: 0.8,
+}
+
+// DefaultLimit is the number of hits returned when the query sets none.
+const DefaultLimit = 10
+
+// DefaultMaxOperations is the number of operations an index keeps unless WithMaxOperations sets another.
+const DefaultMaxOperations = 5000
+
+// Query is a search over the indexed operations.
+type Query struct {
+	// Text is matched against the merchant names and descriptions. Amounts in the text, numbers with a
+	// currency marker or decimals such as "23€" or "12,50", are matched against the operation amounts instead.
+	Text string
+	// Amount is matched approximately, within Tolerance, against the absolute operation amounts.
+	Amount *domain.Money
+	// Tolerance defaults to 5% of the amount, and at least 1 unit of the currency.
+	Tolerance *domain.Money
+	Limit     int
+}
+
+// FieldMatch is a field of an operation that matched the query.
+type FieldMatch struct {
+	Field string `json:"field"`
+	Value string `json:"value"`
+	// Highlighted is the value with the matching words surrounded by ** markers.
+	Highlighted string `json:"highlighted"`
+}
+
+// Hit is an operation that matched the query.
+type Hit struct {
+	Operation domain.Operation `json:"operation"`
+	// Score is between 0 and 1, 1 being an exact match of every term.
+	Score   float64      `json:"score"`
+	Matches []FieldMatch `json:"matches"`
+}
+
+// Index is an in-memory index of operations, safe for concurrent use. It keeps the most recent operations
+// up to a maximum, evicting the oldest ones.
+type Index struct {
+	mu            sync.RWMutex
+	documents     map[string]*document
+	maxOperations int
+}
+
+// IndexOption configures an Index.
+type IndexOption func(*Index)
+
+// WithMaxOperations sets the number of operations the index keeps.
+func WithMaxOperations(maxOperations int) IndexOption {
+	return func(i *Index) {
+		if maxOperations > 0 {
+			i.maxOperations = maxOperations
+		}
+	}
+}
+
+type document struct {
+	operation domain.Operation
+	fields    map[string][]word
+}
+
+// NewIndex creates an empty index.
+func NewIndex(opts ...IndexOption) *Index {
+	index := &Index{documents: make(map[string]*document), maxOperations: DefaultMaxOperations}
+	for _, opt := range opts {
+		opt(index)
+	}
+	return index
+}
+
+// Len returns the number of indexed operations.
+func (i *Index) Len() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return len(i.documents)
+}
+
+// OperationsWithin returns the indexed operations executed within any of the ranges, in no particular order.
+func (i *Index) OperationsWithin(ranges ...domain.DateRange) []domain.Operation {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	var operations []domain.Operation
+	for _, document := range i.documents {
+		date := document.operation.ExecutedAt.CalendarDate()
+		if slices.ContainsFunc(ranges, func(r domain.DateRange) bool { return r.Contains(date) }) {
+			operations = append(operations, document.operation)
+		}
+	}
+	return operations
+}
+
+// Add indexes the operations, replacing the ones already indexed with the same ID, and evicts the oldest
+// operations beyond the maximum. It reports whether any of them was new or changed and is still indexed.
+func (i *Index) Add(operations ...domain.Operation) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	var changed []string
+	for _, operation := range operations {
+		if existing, ok := i.documents[operation.ID]; ok && sameOperation(existing.operation, operation) {
+			continue
+		}
+		changed = append(changed, operation.ID)
+		i.documents[operation.ID] = &document{
+			operation: operation,
+			fields: map[string][]word{
+				FieldMerchant:    splitWords(operation.MerchantName),
+				FieldDescription: splitWords(operation.Description),
+			},
+		}
+	}
+	i.evict()
+	return slices.ContainsFunc(changed, func(id string) bool { return i.documents[id] != nil })
+}
+
+// evict removes the oldest operations beyond the maximum.
+func (i *Index) evict() {
+	excess := len(i.documents) - i.maxOperations
+	if excess <= 0 {
+		return
+	}
+	documents := slices.Collect(maps.Values(i.documents))
+	slices.SortFunc(documents, func(a, b *document) int {
+		return a.operation.ExecutedAt.Compare(b.operation.ExecutedAt.Time)
+	})
+	for _, document := range documents[:excess] {
+		delete(i.documents, document.operation.ID)
+	}
+}
+
+func sameOperation(a, b domain.Operation) bool {
+	return a.Status == b.Status && a.Amount == b.Amount && a.MerchantName == b.MerchantName && a.Description == b.Description
+}
+
+// Search returns the operations matching the query, best first and most recent first on ties.
+// Every term of the text must match a field for an operation to be a hit.
+func (i *Index) Search(query Query) []Hit {
+	terms, amounts := parseText(query.Text)
+	if query.Amount != nil {
+		amounts = append(amounts, *query.Amount)
+	}
+	if len(terms) == 0 && len(amounts) == 0 {
+		return []Hit{}
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	i.mu.RLock()
+	hits := make([]Hit, 0)
+	for _, doc := range i.documents {
+		if hit, ok := doc.match(terms, amounts, query.Tolerance); ok {
+			hits = append(hits, hit)
+		}
+	}
+	i.mu.RUnlock()
+
+	slices.SortFunc(hits, func(a, b Hit) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return b.Operation.ExecutedAt.Compare(a.Operation.ExecutedAt.Time)
+	})
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
+
+func (d *document) match(terms []string, amounts []domain.Money, tolerance *domain.Money) (Hit, bool) {
+	hit := Hit{Operation: d.operation, Matches: []FieldMatch{}}
+	var total float64
+
+	if len(terms) > 0 {
+		textScore, matches, ok := d.matchTerms(terms)
+		if !ok {
+			return Hit{}, false
+		}
+		total += textScore * float64(len(terms))
+		hit.Matches = append(hit.Matches, matches...)
+	}
+
+	for _, amount := range amounts {
+		score := amountScore(d.operation.Amount, amount, tolerance)
+		if score == 0 {
+			return Hit{}, false
+		}
+		total += score
+		hit.Matches = append(hit.Matches, FieldMatch{
+			Field:       FieldAmount,
+			Value:       d.operation.Amount.String(),
+			Highlighted: "**" + d.operation.Amount.String() + "**",
+		})
+	}
+
+	hit.Score = total / float64(len(terms)+len(amounts))
+	return hit, true
+}
+
+// matchTerms scores every term against its best field, and fails if any term matches none.
+func (d *document) matchTerms(terms []string) (float64, []FieldMatch, bool) {
+	highlighted := make(map[string]map[int]bool)
+	var total float64
+	for _, term := range terms {
+		best, bestField, bestWord := 0.0, "", -1
+		for field, words := range d.fields {
+			for index, w := range words {
+				if score := termScore(term, w.folded) * fieldWeights[field]; score > best {
+					best, bestField, bestWord = score, field, index
+				}
+			}
+		}
+		if best == 0 {
+			return 0, nil, false
+		}
+		total += best
+		if highlighted[bestField] == nil {
+			highlighted[bestField] = make(map[int]bool)
+		}
+		highlighted[bestField][bestWord] = true
+	}
+
+	var matches []FieldMatch
+	for _, field := range []string{FieldMerchant, FieldDescription} {
+		if words, ok := highlighted[field]; ok {
+			value := d.fieldValue(field)
+			matches = append(matches, FieldMatch{Field: field, Value: value, Highlighted: highlight(value, d.fields[field], words)})
+		}
+	}
+	return total / float64(len(terms)), matches, true
+}
+
+func (d *document) fieldValue(field string) string {
+	if field == FieldMerchant {
+		return d.operation.MerchantName
+	}
+	return d.operation.Description
+}
+
+// termScore scores how well a query term matches a word, both folded: 1 for an exact match,
+// less for a prefix, a substring or a word within a small edit distance, and 0 otherwise.
+func termScore(term, word string) float64 {
+	switch {
+	case term == word:
+		return 1
+	case strings.HasPrefix(word, term) && len([]rune(term)) >= 2:
+		return 0.9
+	case strings.Contains(word, term) && len([]rune(term)) >= 3:
+		return 0.75
+	}
+
+	termLength, wordLength := len([]rune(term)), len([]rune(word))
+	allowed := 0
+	switch {
+	case termLength >= 8:
+		allowed = 2
+	case termLength >= 4:
+		allowed = 1
+	}
+	if allowed == 0 || abs(termLength-wordLength) > allowed {
+		return 0
+	}
+	if distance := levenshtein(term, word); distance <= allowed {
+		return 0.7 - 0.1*float64(distance-1)
+	}
+	return 0
+}
+
+// amountScore scores how close the absolute amount is to the searched one: 1 when equal, decreasing
+// linearly to 0 at the tolerance.
+func amountScore(amount, searched domain.Money, tolerance *domain.Money) float64 {
+	amount, searched = amount.Abs(), searched.Abs()
+	if amount.Currency != searched.Currency && searched.Currency != "" {
+		return 0
+	}
+	limit := max(searched.MinorUnits/20, domain.MoneyFromMajor(1, searched.Currency).MinorUnits)
+	if tolerance != nil {
+		limit = max(tolerance.Abs().MinorUnits, 0)
+	}
+	diff := abs64(amount.MinorUnits - searched.MinorUnits)
+	if diff > limit {
+		return 0
+	}
+	if limit == 0 {
+		return 1
+	}
+	return 1 - 0.5*float64(diff)/float64(limit)
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func abs64(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
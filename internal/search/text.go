@@ -0,0 +1,105 @@
+package search
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+// word is a word of a field, with its position in the original value so it can be highlighted.
+type word struct {
+	folded     string
+	start, end int
+}
+
+// splitWords splits the value into its words of letters and digits, folded for comparison.
+func splitWords(value string) []word {
+	var words []word
+	start := -1
+	for index, r := range value {
+		isWordRune := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case isWordRune && start < 0:
+			start = index
+		case !isWordRune && start >= 0:
+			words = append(words, word{folded: domain.FoldText(value[start:index]), start: start, end: index})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		words = append(words, word{folded: domain.FoldText(value[start:]), start: start, end: len(value)})
+	}
+	return words
+}
+
+// amountPattern matches the amounts written in a query, e.g. "23€", "23,50", "€23.5" or "12.30 EUR".
+var amountPattern = regexp.MustCompile(`(?i)^(€|eur)?(\d+)(?:[.,](\d{1,2}))?(€|eur)?$`)
+
+// isCurrency reports whether the query word is a currency marker.
+func isCurrency(field string) bool {
+	return field == "€" || strings.EqualFold(field, "eur") || strings.EqualFold(field, "euro") || strings.EqualFold(field, "euros")
+}
+
+// parseText splits the query text into folded terms and the amounts written in it. A number is only read
+// as an amount when it has decimals or a currency marker next to it, so "fatura 2024" or "loja 0452" search
+// for the number as text.
+func parseText(text string) ([]string, []domain.Money) {
+	var terms []string
+	var amounts []domain.Money
+	fields := strings.Fields(strings.ReplaceAll(text, "€", " € "))
+	for index, field := range fields {
+		if isCurrency(field) {
+			continue
+		}
+		marked := (index > 0 && isCurrency(fields[index-1])) || (index+1 < len(fields) && isCurrency(fields[index+1]))
+		if amount, ok := parseAmount(field, marked); ok {
+			amounts = append(amounts, amount)
+			continue
+		}
+		for _, w := range splitWords(field) {
+			terms = append(terms, w.folded)
+		}
+	}
+	return terms, amounts
+}
+
+// parseAmount reads the field as an amount in euros if it has decimals, a currency marker of its own, or
+// marked is set because a currency marker is next to it.
+func parseAmount(field string, marked bool) (domain.Money, bool) {
+	match := amountPattern.FindStringSubmatch(field)
+	if match == nil || !(marked || match[1] != "" || match[3] != "" || match[4] != "") {
+		return domain.Money{}, false
+	}
+	units, err := strconv.ParseInt(match[2], 10, 64)
+	if err != nil {
+		return domain.Money{}, false
+	}
+	cents := int64(0)
+	if match[3] != "" {
+		fraction := match[3]
+		if len(fraction) == 1 {
+			fraction += "0"
+		}
+		cents, _ = strconv.ParseInt(fraction, 10, 64)
+	}
+	return domain.NewMoney(units*100+cents, domain.DefaultCurrency), true
+}
+
+// highlight surrounds the given words of the value with ** markers.
+func highlight(value string, words []word, selected map[int]bool) string {
+	var builder strings.Builder
+	last := 0
+	for index, w := range words {
+		if !selected[index] {
+			continue
+		}
+		builder.WriteString(value[last:w.start])
+		builder.WriteString("**" + value[w.start:w.end] + "**")
+		last = w.end
+	}
+	builder.WriteString(value[last:])
+	return builder.String()
+}
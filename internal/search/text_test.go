@@ -0,0 +1,68 @@
+package search
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/tembleking/coverflex-mcp/internal/domain"
+)
+
+func TestParseText(t *testing.T) {
+	euros := func(cents int64) domain.Money { return domain.NewMoney(cents, domain.DefaultCurrency) }
+	tests := []struct {
+		text    string
+		terms   []string
+		amounts []domain.Money
+	}{
+		{"Farmácia São João", []string{"farmacia", "sao", "joao"}, nil},
+		{"pingo 23€", []string{"pingo"}, []domain.Money{euros(2300)}},
+		{"€23.5 continente", []string{"continente"}, []domain.Money{euros(2350)}},
+		{"12,30 EUR", nil, []domain.Money{euros(1230)}},
+		{"12 euros", nil, []domain.Money{euros(1200)}},
+		{"€ 7", nil, []domain.Money{euros(700)}},
+		{"uber 8,5", []string{"uber"}, []domain.Money{euros(850)}},
+		// Bare integers are years, store codes or invoice numbers rather than amounts.
+		{"fatura 2024", []string{"fatura", "2024"}, nil},
+		{"loja 0452", []string{"loja", "0452"}, nil},
+		// Three decimals are not cents.
+		{"1.234", []string{"1", "234"}, nil},
+		{"mc-donald's", []string{"mc", "donald", "s"}, nil},
+		{"  ", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			terms, amounts := parseText(tt.text)
+			if !slices.Equal(terms, tt.terms) || !slices.Equal(amounts, tt.amounts) {
+				t.Errorf("parseText(%q) = %q, %v, want %q, %v", tt.text, terms, amounts, tt.terms, tt.amounts)
+			}
+		})
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	value := "Farmácia São João, Lda"
+	words := splitWords(value)
+	if got, want := highlight(value, words, map[int]bool{0: true, 2: true}), "**Farmácia** São **João**, Lda"; got != want {
+		t.Errorf("highlight() = %q, want %q", got, want)
+	}
+}
+
+func TestTermScore(t *testing.T) {
+	tests := []struct {
+		term, word string
+		want       float64
+	}{
+		{"pingo", "pingo", 1},
+		{"pin", "pingo", 0.9},
+		{"ing", "pingo", 0.75},
+		{"pinga", "pingo", 0.7},
+		{"farmacai", "farmacia", 0.6},
+		{"cnt", "continente", 0},
+		{"pa", "pb", 0},
+	}
+	for _, tt := range tests {
+		if got := termScore(tt.term, tt.word); got != tt.want {
+			t.Errorf("termScore(%q, %q) = %v, want %v", tt.term, tt.word, got, tt.want)
+		}
+	}
+}
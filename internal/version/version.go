@@ -0,0 +1,22 @@
+package version
+
+import "runtime/debug"
+
+// Version is the release version of coverflex-mcp.
+// It is meant to be set at build time with:
+//
+//	-ldflags "-X github.com/tembleking/coverflex-mcp/internal/version.Version=x.y.z"
+var Version = ""
+
+// String returns the version of the running binary.
+// It falls back to the module version recorded in the build info (e.g. when installed
+// with 'go run ...@latest') and to "dev" when no version information is available.
+func String() string {
+	if Version != "" {
+		return Version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return "dev"
+}
@@ -8,6 +8,12 @@ buildGoModule rec {
 
   src = ./.;
 
+  ldflags = [
+    "-s"
+    "-w"
+    "-X github.com/tembleking/coverflex-mcp/internal/version.Version=${version}"
+  ];
+
   vendorHash = "sha256-v60oPY65xqAQgEPg4AE8uNiliQCFxdOBxj7Wyl1Q4r0=";
 
   meta = with lib; {